@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/go-openapi/strfmt"
@@ -10,6 +12,7 @@ import (
 	"github.com/haproxytech/client-native/v6/configuration/options"
 	"github.com/haproxytech/client-native/v6/models"
 	"github.com/six-group/haproxy-operator/pkg/hash"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -25,6 +28,10 @@ type BackendSpec struct {
 	Servers []Server `json:"servers,omitempty"`
 	// ServerTemplates defines the backend server templates and its configuration.
 	ServerTemplates []ServerTemplate `json:"serverTemplates,omitempty"`
+	// ServiceRef discovers the backend servers from the EndpointSlices of a Kubernetes Service. One server is
+	// rendered per serving endpoint, which replaces the kube-proxy hop.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
 	// Balance defines the load balancing algorithm to be used in a backend.
 	// +optional
 	Balance *Balance `json:"balance,omitempty"`
@@ -37,6 +44,25 @@ type BackendSpec struct {
 	// Redispatch enable or disable session redistribution in case of connection failure
 	// +optional
 	Redispatch *bool `json:"redispatch,omitempty"`
+	// RedispatchInterval is the number of retries after which a connection is redispatched to another server.
+	// A negative value redispatches on the last retry. Defaults to 3.
+	// +optional
+	RedispatchInterval *int64 `json:"redispatchInterval,omitempty"`
+	// Retries is the number of retries to perform on a server after a failure.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries *int64 `json:"retries,omitempty"`
+	// RetryOn specifies the conditions which trigger a retry.
+	// +kubebuilder:validation:items:Enum=none;conn-failure;empty-response;junk-response;response-timeout;"0rtt-rejected";all-retryable-errors;"401";"403";"404";"408";"425";"500";"501";"502";"503";"504"
+	// +optional
+	RetryOn []string `json:"retryOn,omitempty"`
+	// HTTPReuse declares how idle HTTP connections to servers may be shared between requests.
+	// +kubebuilder:validation:Enum=never;safe;aggressive;always
+	// +optional
+	HTTPReuse string `json:"httpReuse,omitempty"`
+	// AbortOnClose enables early dropping of aborted requests pending in queues.
+	// +optional
+	AbortOnClose *bool `json:"abortOnClose,omitempty"`
 	// HashType specifies a method to use for mapping hashes to servers
 	// +optional
 	HashType *HashType `json:"hashType,omitempty"`
@@ -49,6 +75,120 @@ type BackendSpec struct {
 	// TCPCheck Perform health checks using tcp-check send/expect sequences
 	// +optional
 	TCPCheck *bool `json:"tcpCheck,omitempty"`
+	// HTTPCheckRules defines a sequence of http-check rules. It enables HTTP health checks and cannot be combined
+	// with httpchk or TCP health checks.
+	// +optional
+	HTTPCheckRules []HTTPCheckRule `json:"httpCheckRules,omitempty"`
+	// TCPCheckRules defines a sequence of tcp-check rules. It enables TCP health checks and cannot be combined with
+	// HTTP health checks.
+	// +optional
+	TCPCheckRules []TCPCheckRule `json:"tcpCheckRules,omitempty"`
+	// FullConn is the number of connections at which the backend is considered at full load. It is used to compute
+	// the dynamic maxconn of servers with MinConn.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FullConn *int64 `json:"fullconn,omitempty"`
+	// AllBackups uses all backup servers at once when all non-backup servers are unavailable, instead of only the
+	// first one.
+	// +optional
+	AllBackups *bool `json:"allBackups,omitempty"`
+}
+
+type ServiceReference struct {
+	ServerParams `json:",inline"`
+	// Name of the Service.
+	Name string `json:"name"`
+	// Namespace of the Service. Defaults to the namespace of the backend.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Port is the name of the Service port. It can be omitted if the Service exposes a single port.
+	// +optional
+	Port string `json:"port,omitempty"`
+	// Zone the HAProxy instance is running in. If set, endpoints with topology hints for other zones are
+	// only used as backup servers.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+}
+
+// Servers returns one server per serving endpoint of the given EndpointSlices. Ready endpoints are normal servers,
+// terminating endpoints which are still serving are backup servers. IPv6 endpoints are only used if the Service
+// has no IPv4 endpoints.
+func (s *ServiceReference) Servers(endpointSlices []discoveryv1.EndpointSlice) ([]Server, error) {
+	addressType := discoveryv1.AddressTypeIPv6
+	if slices.ContainsFunc(endpointSlices, func(slice discoveryv1.EndpointSlice) bool {
+		return slice.AddressType == discoveryv1.AddressTypeIPv4
+	}) {
+		addressType = discoveryv1.AddressTypeIPv4
+	}
+
+	var servers []Server
+	for _, slice := range endpointSlices {
+		if slice.AddressType != addressType || len(slice.Endpoints) == 0 {
+			continue
+		}
+
+		port, err := s.endpointPort(slice.Ports)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			ready := ptr.Deref(endpoint.Conditions.Ready, true)
+			if !ptr.Deref(endpoint.Conditions.Serving, ready) || len(endpoint.Addresses) == 0 {
+				continue
+			}
+
+			server := Server{
+				ServerParams: *s.ServerParams.DeepCopy(),
+				Name:         serverName(endpoint),
+				Address:      endpoint.Addresses[0],
+				Port:         int64(port),
+			}
+			if !ready || ptr.Deref(endpoint.Conditions.Terminating, false) || !s.inZone(endpoint) {
+				server.Backup = ptr.To(true)
+			}
+
+			servers = append(servers, server)
+		}
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+
+	return servers, nil
+}
+
+func (s *ServiceReference) endpointPort(ports []discoveryv1.EndpointPort) (int32, error) {
+	for _, port := range ports {
+		if port.Port == nil {
+			continue
+		}
+		if ptr.Deref(port.Name, "") == s.Port || (s.Port == "" && len(ports) == 1) {
+			return *port.Port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("port '%s' not found in service %s", s.Port, s.Name)
+}
+
+// inZone returns false if the endpoint has topology hints which do not include the zone of the reference.
+func (s *ServiceReference) inZone(endpoint discoveryv1.Endpoint) bool {
+	if s.Zone == "" || endpoint.Hints == nil || len(endpoint.Hints.ForZones) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(endpoint.Hints.ForZones, func(zone discoveryv1.ForZone) bool {
+		return zone.Name == s.Zone
+	})
+}
+
+func serverName(endpoint discoveryv1.Endpoint) string {
+	if endpoint.TargetRef != nil && endpoint.TargetRef.Name != "" {
+		return endpoint.TargetRef.Name
+	}
+
+	return strings.NewReplacer(".", "-", ":", "-").Replace(endpoint.Addresses[0])
 }
 
 //+kubebuilder:object:root=true
@@ -83,6 +223,10 @@ func (b *Backend) Model() (models.Backend, error) {
 		},
 	}
 
+	if err := b.checkHealthChecks(); err != nil {
+		return model, err
+	}
+
 	if b.Spec.CheckTimeout != nil {
 		model.CheckTimeout = ptr.To(b.Spec.CheckTimeout.Milliseconds())
 	}
@@ -106,10 +250,18 @@ func (b *Backend) Model() (models.Backend, error) {
 			URI:    b.Spec.HTTPChk.URI,
 			Method: b.Spec.HTTPChk.Method,
 		}
-	} else if b.Spec.TCPCheck != nil && *b.Spec.TCPCheck {
+	} else if len(b.Spec.HTTPCheckRules) > 0 {
+		model.AdvCheck = models.BackendBaseAdvCheckHttpchk
+	} else if (b.Spec.TCPCheck != nil && *b.Spec.TCPCheck) || len(b.Spec.TCPCheckRules) > 0 {
 		model.AdvCheck = models.BackendBaseAdvCheckTCPDashCheck
 	}
 
+	model.Fullconn = b.Spec.FullConn
+
+	if ptr.Deref(b.Spec.AllBackups, false) {
+		model.Allbackups = models.BackendBaseAllbackupsEnabled
+	}
+
 	if b.Spec.HTTPPretendKeepalive != nil && *b.Spec.HTTPPretendKeepalive {
 		model.HTTPPretendKeepalive = models.BackendBaseHTTPPretendKeepaliveEnabled
 	}
@@ -117,10 +269,18 @@ func (b *Backend) Model() (models.Backend, error) {
 	if b.Spec.Redispatch != nil && *b.Spec.Redispatch {
 		model.Redispatch = &models.Redispatch{
 			Enabled:  ptr.To(models.RedispatchEnabledEnabled),
-			Interval: ptr.To(int64(3)),
+			Interval: ptr.To(ptr.Deref(b.Spec.RedispatchInterval, 3)),
 		}
 	}
 
+	model.Retries = b.Spec.Retries
+	model.RetryOn = strings.Join(b.Spec.RetryOn, " ")
+	model.HTTPReuse = b.Spec.HTTPReuse
+
+	if ptr.Deref(b.Spec.AbortOnClose, false) {
+		model.Abortonclose = models.BackendBaseAbortoncloseEnabled
+	}
+
 	if b.Spec.HashType != nil {
 		ht, err := b.Spec.HashType.Model()
 		if err == nil {
@@ -129,8 +289,17 @@ func (b *Backend) Model() (models.Backend, error) {
 	}
 
 	if b.Spec.Balance != nil {
-		model.Balance = &models.Balance{
-			Algorithm: ptr.To(strings.ToLower(b.Spec.Balance.Algorithm)),
+		balance, err := b.Spec.Balance.Model()
+		if err != nil {
+			return model, err
+		}
+		model.Balance = &balance
+
+		if b.Spec.Balance.HashBalanceFactor != nil {
+			if b.Spec.HashType == nil || b.Spec.HashType.Method != "consistent" {
+				return model, fmt.Errorf("hash balance factor requires the consistent hash type method")
+			}
+			model.HashBalanceFactor = b.Spec.Balance.HashBalanceFactor
 		}
 	}
 
@@ -209,6 +378,21 @@ func (b *Backend) Model() (models.Backend, error) {
 	return model, model.Validate(strfmt.Default)
 }
 
+// checkHealthChecks verifies that a backend uses either HTTP or TCP health checks, and that HTTP checks are configured
+// either by httpchk or by a sequence of http-check rules.
+func (b *Backend) checkHealthChecks() error {
+	httpCheck := b.Spec.HTTPChk != nil || len(b.Spec.HTTPCheckRules) > 0
+	tcpCheck := ptr.Deref(b.Spec.TCPCheck, false) || len(b.Spec.TCPCheckRules) > 0
+
+	switch {
+	case httpCheck && tcpCheck:
+		return fmt.Errorf("http checks and tcp checks cannot be combined")
+	case b.Spec.HTTPChk != nil && len(b.Spec.HTTPCheckRules) > 0:
+		return fmt.Errorf("httpchk cannot be combined with httpCheckRules, use a send rule instead")
+	}
+	return nil
+}
+
 func (b *Backend) AddToParser(p parser.Parser) error {
 	err := p.SectionsCreate(parser.Backends, b.Name)
 	if err != nil {
@@ -232,6 +416,10 @@ func (b *Backend) AddToParser(p parser.Parser) error {
 	}
 
 	for idx, server := range b.Spec.Servers {
+		if err := checkProtocolMode(server.Protocol, b.Spec.Mode); err != nil {
+			return err
+		}
+
 		model, err := server.Model()
 
 		if server.SSL != nil && server.SSL.Verify == "required" {
@@ -250,6 +438,10 @@ func (b *Backend) AddToParser(p parser.Parser) error {
 	}
 
 	for idx, template := range b.Spec.ServerTemplates {
+		if err := checkProtocolMode(template.Protocol, b.Spec.Mode); err != nil {
+			return err
+		}
+
 		model, err := template.Model()
 		if err != nil {
 			return err
@@ -261,6 +453,40 @@ func (b *Backend) AddToParser(p parser.Parser) error {
 		}
 	}
 
+	for idx, rule := range b.Spec.HTTPCheckRules {
+		model, err := rule.Model()
+		if err != nil {
+			return err
+		}
+
+		action, err := configuration.SerializeHTTPCheck(model)
+		if err != nil {
+			return err
+		}
+
+		err = p.Insert(parser.Backends, b.Name, "http-check", action, idx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for idx, rule := range b.Spec.TCPCheckRules {
+		model, err := rule.Model()
+		if err != nil {
+			return err
+		}
+
+		action, err := configuration.SerializeTCPCheck(model)
+		if err != nil {
+			return err
+		}
+
+		err = p.Insert(parser.Backends, b.Name, "tcp-check", action, idx)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
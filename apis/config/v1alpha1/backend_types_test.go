@@ -8,6 +8,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -690,5 +692,369 @@ var _ = Describe("Backend", Label("type"), func() {
 			Ω(p.String()).Should(ContainSubstring("tcp-request inspect-delay 5000\n"))
 			Ω(p.String()).Should(ContainSubstring("tcp-request content accept if { req_ssl_hello_type 1 }\n"))
 		})
+		It("should discover servers from endpoint slices", func() {
+			ref := &configv1alpha1.ServiceReference{
+				ServerParams: configv1alpha1.ServerParams{Check: &configv1alpha1.Check{Enabled: true}},
+				Name:         "web",
+				Port:         "http",
+				Zone:         "zone-a",
+			}
+			servers, err := ref.Servers([]discoveryv1.EndpointSlice{
+				{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Ports: []discoveryv1.EndpointPort{
+						{Name: ptr.To("metrics"), Port: ptr.To(int32(9090))},
+						{Name: ptr.To("http"), Port: ptr.To(int32(8080))},
+					},
+					Endpoints: []discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.0.0.1"},
+							Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+							TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-1"},
+						},
+						{
+							Addresses:  []string{"10.0.0.2"},
+							Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false), Serving: ptr.To(true), Terminating: ptr.To(true)},
+							TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: "web-2"},
+						},
+						{
+							Addresses:  []string{"10.0.0.3"},
+							Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false), Serving: ptr.To(false)},
+						},
+						{
+							Addresses:  []string{"10.0.0.4"},
+							Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+							Hints:      &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone-b"}}},
+						},
+					},
+				},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(servers).Should(HaveLen(3))
+
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec:       configv1alpha1.BackendSpec{Servers: servers},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(MatchRegexp(`server 10-0-0-4 10\.0\.0\.4:8080 .*backup`))
+			Ω(p.String()).Should(ContainSubstring("server web-1 10.0.0.1:8080 check\n"))
+			Ω(p.String()).Should(MatchRegexp(`server web-2 10\.0\.0\.2:8080 .*backup`))
+		})
+		It("should fail on unknown service port", func() {
+			ref := &configv1alpha1.ServiceReference{Name: "web", Port: "https"}
+			_, err := ref.Servers([]discoveryv1.EndpointSlice{
+				{
+					AddressType: discoveryv1.AddressTypeIPv4,
+					Ports:       []discoveryv1.EndpointPort{{Name: ptr.To("http"), Port: ptr.To(int32(8080))}},
+					Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.1"}}},
+				},
+			})
+			Ω(err).Should(HaveOccurred())
+		})
+		It("should set http-check sequence", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					HTTPCheckRules: []configv1alpha1.HTTPCheckRule{
+						{
+							Type:    "send",
+							Method:  "GET",
+							URI:     "/health",
+							Version: "HTTP/1.1",
+							Headers: []configv1alpha1.CheckHeader{{Name: "Host", Value: "example.com"}},
+						},
+						{
+							Type:   "expect",
+							Expect: &configv1alpha1.CheckExpect{Match: "status", Pattern: "200-399"},
+						},
+						{
+							Type:   "expect",
+							Expect: &configv1alpha1.CheckExpect{Match: "rstring", Pattern: "DOWN", Negate: true},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("option httpchk\n"))
+			Ω(p.String()).Should(ContainSubstring("http-check send meth GET uri /health ver HTTP/1.1 hdr Host example.com\n"))
+			Ω(p.String()).Should(ContainSubstring("http-check expect status 200-399\n"))
+			Ω(p.String()).Should(ContainSubstring("http-check expect ! rstring DOWN\n"))
+		})
+		It("should set tcp-check sequence", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "redis"},
+				Spec: configv1alpha1.BackendSpec{
+					TCPCheckRules: []configv1alpha1.TCPCheckRule{
+						{
+							Action:  "connect",
+							Connect: &configv1alpha1.CheckConnect{Port: ptr.To(int64(6379))},
+						},
+						{
+							Action: "send",
+							Data:   "PING\\r\\n",
+						},
+						{
+							Action: "expect",
+							Expect: &configv1alpha1.CheckExpect{Match: "string", Pattern: "+PONG"},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("option tcp-check\n"))
+			Ω(p.String()).Should(ContainSubstring("tcp-check connect port 6379\n"))
+			Ω(p.String()).Should(ContainSubstring("tcp-check send PING\\r\\n\n"))
+			Ω(p.String()).Should(ContainSubstring("tcp-check expect string +PONG\n"))
+		})
+		It("should not combine health check types", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "mixed"},
+				Spec: configv1alpha1.BackendSpec{
+					HTTPCheckRules: []configv1alpha1.HTTPCheckRule{{Type: "send", Method: "GET", URI: "/health"}},
+					TCPCheckRules:  []configv1alpha1.TCPCheckRule{{Action: "connect"}},
+				},
+			}
+			Ω(backend.AddToParser(p)).Should(HaveOccurred())
+
+			backend.Spec.TCPCheckRules = nil
+			backend.Spec.TCPCheck = ptr.To(true)
+			_, err := backend.Model()
+			Ω(err).Should(HaveOccurred())
+
+			backend.Spec.TCPCheck = nil
+			backend.Spec.HTTPChk = &configv1alpha1.HTTPChk{URI: "/health"}
+			_, err = backend.Model()
+			Ω(err).Should(HaveOccurred())
+		})
+		It("should not allow status match in tcp-check", func() {
+			rule := configv1alpha1.TCPCheckRule{
+				Action: "expect",
+				Expect: &configv1alpha1.CheckExpect{Match: "status", Pattern: "200"},
+			}
+			_, err := rule.Model()
+			Ω(err).Should(HaveOccurred())
+		})
+		It("should set server check options", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					Servers: []configv1alpha1.Server{
+						{
+							Name:    "web",
+							Address: "10.0.0.1",
+							Port:    8080,
+							ServerParams: configv1alpha1.ServerParams{
+								Check: &configv1alpha1.Check{
+									Enabled: true,
+									SSL:     ptr.To(true),
+									Proto:   "h2",
+									Port:    ptr.To(int64(8443)),
+								},
+							},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("check-ssl"))
+			Ω(p.String()).Should(ContainSubstring("check-proto h2"))
+			Ω(p.String()).Should(ContainSubstring("port 8443"))
+		})
+		It("should set server agent check", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					ServerTemplates: []configv1alpha1.ServerTemplate{
+						{
+							Prefix: "web",
+							NumMin: ptr.To(int64(1)),
+							Num:    3,
+							FQDN:   "web.svc",
+							Port:   8080,
+							ServerParams: configv1alpha1.ServerParams{
+								AgentCheck: &configv1alpha1.AgentCheck{
+									Enabled: true,
+									Port:    9999,
+									Inter:   &metav1.Duration{Duration: 5 * time.Second},
+									Send:    "web",
+								},
+							},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("agent-check"))
+			Ω(p.String()).Should(ContainSubstring("agent-port 9999"))
+			Ω(p.String()).Should(ContainSubstring("agent-inter 5000"))
+			Ω(p.String()).Should(ContainSubstring("agent-send web"))
+		})
+		It("should set server capacity controls", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					FullConn:   ptr.To(int64(1000)),
+					AllBackups: ptr.To(true),
+					Servers: []configv1alpha1.Server{
+						{
+							Name:    "primary",
+							Address: "10.0.0.1",
+							Port:    8080,
+							ServerParams: configv1alpha1.ServerParams{
+								MaxConn:    ptr.To(int64(100)),
+								MaxQueue:   ptr.To(int64(50)),
+								MinConn:    ptr.To(int64(10)),
+								SlowStart:  &metav1.Duration{Duration: 30 * time.Second},
+								Observe:    "layer7",
+								ErrorLimit: ptr.To(int64(10)),
+								OnError:    "mark-down",
+							},
+						},
+						{
+							Name:    "standby",
+							Address: "10.0.0.2",
+							Port:    8080,
+							ServerParams: configv1alpha1.ServerParams{
+								Backup:   ptr.To(true),
+								Disabled: ptr.To(true),
+								Track:    "primary",
+							},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("fullconn 1000\n"))
+			Ω(p.String()).Should(ContainSubstring("option allbackups\n"))
+			Ω(p.String()).Should(ContainSubstring("maxconn 100"))
+			Ω(p.String()).Should(ContainSubstring("maxqueue 50"))
+			Ω(p.String()).Should(ContainSubstring("minconn 10"))
+			Ω(p.String()).Should(ContainSubstring("slowstart 30000"))
+			Ω(p.String()).Should(ContainSubstring("observe layer7"))
+			Ω(p.String()).Should(ContainSubstring("error-limit 10"))
+			Ω(p.String()).Should(ContainSubstring("on-error mark-down"))
+			Ω(p.String()).Should(MatchRegexp(`server standby 10\.0\.0\.2:8080 .*backup`))
+			Ω(p.String()).Should(MatchRegexp(`server standby .*disabled`))
+			Ω(p.String()).Should(MatchRegexp(`server standby .*track primary`))
+		})
+		It("should set retry and connection reuse settings", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					Redispatch:         ptr.To(true),
+					RedispatchInterval: ptr.To(int64(-1)),
+					Retries:            ptr.To(int64(5)),
+					RetryOn:            []string{"conn-failure", "empty-response", "503"},
+					HTTPReuse:          "safe",
+					AbortOnClose:       ptr.To(true),
+					Servers: []configv1alpha1.Server{
+						{
+							Name:    "web",
+							Address: "10.0.0.1",
+							Port:    8080,
+							ServerParams: configv1alpha1.ServerParams{
+								PoolMaxConn:    ptr.To(int64(100)),
+								PoolLowConn:    ptr.To(int64(10)),
+								PoolPurgeDelay: &metav1.Duration{Duration: 10 * time.Second},
+							},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("option redispatch -1\n"))
+			Ω(p.String()).Should(ContainSubstring("retries 5\n"))
+			Ω(p.String()).Should(ContainSubstring("retry-on conn-failure empty-response 503\n"))
+			Ω(p.String()).Should(ContainSubstring("http-reuse safe\n"))
+			Ω(p.String()).Should(ContainSubstring("option abortonclose\n"))
+			Ω(p.String()).Should(ContainSubstring("pool-max-conn 100"))
+			Ω(p.String()).Should(ContainSubstring("pool-low-conn 10"))
+			Ω(p.String()).Should(ContainSubstring("pool-purge-delay 10000"))
+		})
+		It("should set balance algorithm parameters", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					Balance: &configv1alpha1.Balance{
+						Algorithm: "hdr",
+						Hdr:       &configv1alpha1.BalanceHdr{Name: "Host", UseDomainOnly: true},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("balance hdr(Host) use_domain_only\n"))
+		})
+		It("should set consistent hash balance factor", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					HashType: &configv1alpha1.HashType{Method: "consistent"},
+					Balance: &configv1alpha1.Balance{
+						Algorithm:         "hash",
+						Hash:              &configv1alpha1.BalanceHash{Expression: "req.cookie(session)"},
+						HashBalanceFactor: ptr.To(int64(150)),
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("balance hash req.cookie(session)\n"))
+			Ω(p.String()).Should(ContainSubstring("hash-balance-factor 150\n"))
+		})
+		It("should validate balance algorithm parameters", func() {
+			Ω((&configv1alpha1.Balance{Algorithm: "url_param"}).Model()).Error().Should(HaveOccurred())
+			Ω((&configv1alpha1.Balance{Algorithm: "roundrobin", Hdr: &configv1alpha1.BalanceHdr{Name: "Host"}}).Model()).Error().Should(HaveOccurred())
+
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					Balance: &configv1alpha1.Balance{Algorithm: "roundrobin", HashBalanceFactor: ptr.To(int64(150))},
+				},
+			}
+			Ω(backend.AddToParser(p)).Should(HaveOccurred())
+		})
+		It("should set server protocol", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "grpc"},
+				Spec: configv1alpha1.BackendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					Servers: []configv1alpha1.Server{
+						{
+							Name:         "grpc",
+							Address:      "10.0.0.1",
+							Port:         9000,
+							ServerParams: configv1alpha1.ServerParams{Protocol: "h2"},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("server grpc 10.0.0.1:9000 proto h2\n"))
+		})
+		It("should validate server protocol against mode and SSL", func() {
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "grpc"},
+				Spec: configv1alpha1.BackendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "tcp"},
+					Servers: []configv1alpha1.Server{
+						{
+							Name:         "grpc",
+							Address:      "10.0.0.1",
+							Port:         9000,
+							ServerParams: configv1alpha1.ServerParams{Protocol: "h2"},
+						},
+					},
+				},
+			}
+			Ω(backend.AddToParser(p)).Should(HaveOccurred())
+
+			server := configv1alpha1.Server{
+				Name:         "quic",
+				Address:      "10.0.0.1",
+				Port:         443,
+				ServerParams: configv1alpha1.ServerParams{Protocol: "quic"},
+			}
+			Ω(server.Model()).Error().Should(HaveOccurred())
+		})
 	})
 })
@@ -2,12 +2,14 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/go-openapi/strfmt"
 	parser "github.com/haproxytech/client-native/v6/config-parser"
+	"github.com/haproxytech/client-native/v6/config-parser/types"
 	"github.com/haproxytech/client-native/v6/configuration"
 	"github.com/haproxytech/client-native/v6/configuration/options"
 	"github.com/haproxytech/client-native/v6/models"
@@ -29,6 +31,10 @@ type Object interface {
 }
 
 type BaseSpec struct {
+	// Defaults references a Defaults object whose named defaults section is inherited by this proxy using 'from'.
+	// Proxies without a reference inherit from the defaults section of the instance.
+	// +optional
+	Defaults *corev1.LocalObjectReference `json:"defaults,omitempty"`
 	// Mode can be either 'tcp' or 'http'. In TCP mode it is a layer 4 proxy. In HTTP mode it is a layer 7 proxy.
 	// +kubebuilder:default=http
 	// +kubebuilder:validation:Enum=http;tcp
@@ -73,6 +79,12 @@ type BaseSpec struct {
 }
 
 func (b *BaseSpec) AddToParser(p parser.Parser, sectionType parser.Section, sectionName string) error {
+	if b.Defaults != nil {
+		if err := p.SectionsDefaultsFromSet(sectionType, sectionName, b.Defaults.Name); err != nil {
+			return err
+		}
+	}
+
 	for idx, acl := range b.ACL {
 		model, err := acl.Model()
 		if err != nil {
@@ -314,6 +326,28 @@ type Bind struct {
 	// the sockets declared on the same line.
 	// +optional
 	AcceptProxy *bool `json:"acceptProxy,omitempty"`
+	// Protocol forces the multiplexer protocol of accepted connections. h1 and h2 require mode http, quic requires SSL.
+	// +kubebuilder:validation:Enum=h1;h2;quic
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// QUIC makes the bind listen for HTTP/3 over QUIC on UDP. It requires SSL. If a TCP bind with the same port
+	// exists in a proxy with mode http, responses on it announce HTTP/3 with an alt-svc header.
+	// +optional
+	QUIC bool `json:"quic,omitempty"`
+
+	// ClientCertificateHeaders forwards details of verified client certificates to the backends. It requires
+	// mode http and SSL with verify optional or required.
+	// +optional
+	ClientCertificateHeaders *ClientCertificateHeaders `json:"clientCertificateHeaders,omitempty"`
+}
+
+// QUICAddress returns the bind address prefixed with the QUIC address family.
+func (b *Bind) QUICAddress() string {
+	if strings.Contains(b.Address, ":") {
+		return "quic6@" + b.Address
+	}
+
+	return "quic4@" + strings.TrimPrefix(b.Address, "*")
 }
 
 func (b *Bind) Model() (models.Bind, error) {
@@ -336,9 +370,11 @@ func (b *Bind) Model() (models.Bind, error) {
 			model.CrtList = b.SSLCertificateList.FilePath()
 		}
 
-		if b.SSL.Certificate != nil {
-			model.SslCertificate = b.SSL.Certificate.FilePath()
+		certificate, err := b.SSL.certificate()
+		if err != nil {
+			return model, err
 		}
+		model.SslCertificate = certificate
 
 		if b.SSL.CACertificate != nil {
 			model.SslCafile = b.SSL.CACertificate.FilePath()
@@ -347,11 +383,59 @@ func (b *Bind) Model() (models.Bind, error) {
 		if b.SSL.MinVersion != "" {
 			model.SslMinVer = b.SSL.MinVersion
 		}
+
+		if b.SSL.CRLFile != nil {
+			model.CrlFile = b.SSL.CRLFile.FilePath()
+		}
+
+		if b.SSL.CAVerifyFile != nil {
+			model.CaVerifyFile = b.SSL.CAVerifyFile.FilePath()
+		}
+
+		model.Ciphers = b.SSL.Ciphers
+		model.Ciphersuites = b.SSL.Ciphersuites
+		model.Curves = b.SSL.Curves
+		model.Sigalgs = b.SSL.Sigalgs
+
+		if b.SSL.TLSTicketKeys {
+			model.TLSTicketKeys = TLSTicketKeysFilePath
+		}
+	}
+
+	if b.Protocol != "" {
+		if err := checkProtocolSSL(b.Protocol, b.SSL); err != nil {
+			return model, err
+		}
+		model.Proto = b.Protocol
+	}
+
+	if b.QUIC {
+		if err := checkProtocolSSL("quic", b.SSL); err != nil {
+			return model, err
+		}
+		model.Address = b.QUICAddress()
+		model.Alpn = "h3"
 	}
 
 	return model, model.Validate(strfmt.Default)
 }
 
+// checkProtocolSSL verifies that QUIC is only used with SSL.
+func checkProtocolSSL(protocol string, ssl *SSL) error {
+	if protocol == "quic" && (ssl == nil || !ssl.Enabled) {
+		return fmt.Errorf("protocol quic requires SSL")
+	}
+	return nil
+}
+
+// checkProtocolMode verifies that HTTP protocols are not used in a proxy with mode tcp.
+func checkProtocolMode(protocol, mode string) error {
+	if protocol != "" && mode == "tcp" {
+		return fmt.Errorf("protocol %s requires mode http", protocol)
+	}
+	return nil
+}
+
 type ServerParams struct {
 	// SSL configures OpenSSL
 	// +optional
@@ -365,6 +449,10 @@ type ServerParams struct {
 	// Check configures the health checks of the server.
 	// +optional
 	Check *Check `json:"check,omitempty"`
+	// AgentCheck configures an agent health check. The agent running on the server reports its state and weight,
+	// which allows the application to drain itself or adjust its load.
+	// +optional
+	AgentCheck *AgentCheck `json:"agentCheck,omitempty"`
 	// InitAddr indicates in what order the server address should be resolved upon startup if it uses an FQDN.
 	// Attempts are made to resolve the address by applying in turn each of the methods mentioned in the comma-delimited
 	// list. The first method which succeeds is used.
@@ -401,6 +489,61 @@ type ServerParams struct {
 	// HAProxy will prefer using an IP address from the ipv4 or ipv6.
 	// +optional
 	ResolvePrefer string `json:"resolvePrefer,omitempty"`
+	// Backup marks the server as a backup server. It is only used in load balancing when all other non-backup
+	// servers are unavailable.
+	// +optional
+	Backup *bool `json:"backup,omitempty"`
+	// MaxConn is the maximum number of concurrent connections sent to the server. Excess connections are queued.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConn *int64 `json:"maxconn,omitempty"`
+	// MaxQueue is the maximum number of connections waiting in the queue of the server. Excess connections are
+	// redispatched to other servers or rejected.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxQueue *int64 `json:"maxqueue,omitempty"`
+	// MinConn enables a dynamic maxconn limit. The limit grows from MinConn to MaxConn with the load of the backend
+	// relative to its fullconn.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinConn *int64 `json:"minconn,omitempty"`
+	// SlowStart is the time the weight of a server needs to grow to its full value after it came back up.
+	// +optional
+	SlowStart *metav1.Duration `json:"slowstart,omitempty"`
+	// Disabled starts the server in maintenance mode.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+	// OnError defines the action taken when the ErrorLimit of consecutive errors is reached.
+	// +kubebuilder:validation:Enum=fastinter;fail-check;sudden-death;mark-down
+	// +optional
+	OnError string `json:"onError,omitempty"`
+	// Observe enables health adjusting based on the observed traffic on layer4 or layer7.
+	// +kubebuilder:validation:Enum=layer4;layer7
+	// +optional
+	Observe string `json:"observe,omitempty"`
+	// ErrorLimit is the number of consecutive errors which triggers the OnError action.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ErrorLimit *int64 `json:"errorLimit,omitempty"`
+	// Track sets the state of the server to the state of another server, referenced as backend/server or server.
+	// +optional
+	Track string `json:"track,omitempty"`
+	// PoolMaxConn is the maximum number of idle connections kept for reuse on the server. -1 means unlimited.
+	// +optional
+	PoolMaxConn *int64 `json:"poolMaxConn,omitempty"`
+	// PoolLowConn is the number of idle connections of a thread below which it does not reuse idle connections of
+	// other threads.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PoolLowConn *int64 `json:"poolLowConn,omitempty"`
+	// PoolPurgeDelay is the delay after which half of the idle connections are closed.
+	// +optional
+	PoolPurgeDelay *metav1.Duration `json:"poolPurgeDelay,omitempty"`
+	// Protocol forces the multiplexer protocol of connections to the server, e.g. h2 for cleartext HTTP/2 to gRPC
+	// services. h1, h2 and fcgi require mode http, quic requires SSL.
+	// +kubebuilder:validation:Enum=h1;h2;fcgi;quic
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
 }
 
 type ServerTemplate struct {
@@ -443,6 +586,39 @@ func (s *ServerTemplate) Model() (models.ServerTemplate, error) {
 		model.SendProxy = models.ServerParamsSendProxyEnabled
 	}
 
+	if ptr.Deref(s.Backup, false) {
+		model.Backup = models.ServerParamsBackupEnabled
+	}
+
+	model.Maxconn = s.MaxConn
+	model.Maxqueue = s.MaxQueue
+	model.Minconn = s.MinConn
+	model.OnError = s.OnError
+	model.Observe = s.Observe
+	model.ErrorLimit = ptr.Deref(s.ErrorLimit, 0)
+	model.Track = s.Track
+	model.PoolMaxConn = s.PoolMaxConn
+	model.PoolLowConn = s.PoolLowConn
+
+	if s.PoolPurgeDelay != nil {
+		model.PoolPurgeDelay = ptr.To(s.PoolPurgeDelay.Milliseconds())
+	}
+
+	if s.Protocol != "" {
+		if err := checkProtocolSSL(s.Protocol, s.SSL); err != nil {
+			return model, err
+		}
+		model.Proto = s.Protocol
+	}
+
+	if s.SlowStart != nil {
+		model.Slowstart = ptr.To(s.SlowStart.Milliseconds())
+	}
+
+	if ptr.Deref(s.Disabled, false) {
+		model.Maintenance = models.ServerParamsMaintenanceEnabled
+	}
+
 	if s.SendProxyV2 != nil {
 		if s.SendProxyV2.V1 && s.SendProxyV2.V2 == nil && !s.SendProxyV2.V2SSL && !s.SendProxyV2.V2SSLCN {
 			model.SendProxy = models.ServerParamsSendProxyEnabled
@@ -462,9 +638,11 @@ func (s *ServerTemplate) Model() (models.ServerTemplate, error) {
 		model.Ssl = models.ServerParamsSslEnabled
 		model.Verify = s.SSL.Verify
 
-		if s.SSL.Certificate != nil {
-			model.SslCertificate = s.SSL.Certificate.FilePath()
+		certificate, err := s.SSL.certificate()
+		if err != nil {
+			return model, err
 		}
+		model.SslCertificate = certificate
 
 		if s.SSL.CACertificate == nil {
 			model.Verify = "none"
@@ -479,6 +657,16 @@ func (s *ServerTemplate) Model() (models.ServerTemplate, error) {
 		if s.SSL.SNI != "" {
 			model.Sni = s.SSL.SNI
 		}
+
+		if s.SSL.CRLFile != nil {
+			model.CrlFile = s.SSL.CRLFile.FilePath()
+		}
+
+		model.Verifyhost = s.SSL.VerifyHost
+		model.Ciphers = s.SSL.Ciphers
+		model.Ciphersuites = s.SSL.Ciphersuites
+		model.Curves = s.SSL.Curves
+		model.Sigalgs = s.SSL.Sigalgs
 	}
 
 	if s.Check != nil && s.Check.Enabled {
@@ -490,6 +678,27 @@ func (s *ServerTemplate) Model() (models.ServerTemplate, error) {
 
 		model.Rise = s.Check.Rise
 		model.Fall = s.Check.Fall
+
+		if s.Check.SSL != nil {
+			model.CheckSsl = models.ServerParamsCheckSslDisabled
+			if *s.Check.SSL {
+				model.CheckSsl = models.ServerParamsCheckSslEnabled
+			}
+		}
+		model.CheckProto = s.Check.Proto
+		model.HealthCheckAddress = s.Check.Address
+		model.HealthCheckPort = s.Check.Port
+	}
+
+	if s.AgentCheck != nil && s.AgentCheck.Enabled {
+		model.AgentCheck = models.ServerParamsAgentCheckEnabled
+		model.AgentPort = ptr.To(s.AgentCheck.Port)
+		model.AgentAddr = s.AgentCheck.Address
+		model.AgentSend = s.AgentCheck.Send
+
+		if s.AgentCheck.Inter != nil {
+			model.AgentInter = ptr.To(s.AgentCheck.Inter.Milliseconds())
+		}
 	}
 
 	if s.Resolvers != nil {
@@ -566,6 +775,39 @@ func (s *Server) Model() (models.Server, error) {
 		model.SendProxy = models.ServerParamsSendProxyEnabled
 	}
 
+	if ptr.Deref(s.Backup, false) {
+		model.Backup = models.ServerParamsBackupEnabled
+	}
+
+	model.Maxconn = s.MaxConn
+	model.Maxqueue = s.MaxQueue
+	model.Minconn = s.MinConn
+	model.OnError = s.OnError
+	model.Observe = s.Observe
+	model.ErrorLimit = ptr.Deref(s.ErrorLimit, 0)
+	model.Track = s.Track
+	model.PoolMaxConn = s.PoolMaxConn
+	model.PoolLowConn = s.PoolLowConn
+
+	if s.PoolPurgeDelay != nil {
+		model.PoolPurgeDelay = ptr.To(s.PoolPurgeDelay.Milliseconds())
+	}
+
+	if s.Protocol != "" {
+		if err := checkProtocolSSL(s.Protocol, s.SSL); err != nil {
+			return model, err
+		}
+		model.Proto = s.Protocol
+	}
+
+	if s.SlowStart != nil {
+		model.Slowstart = ptr.To(s.SlowStart.Milliseconds())
+	}
+
+	if ptr.Deref(s.Disabled, false) {
+		model.Maintenance = models.ServerParamsMaintenanceEnabled
+	}
+
 	if s.SendProxyV2 != nil {
 		if s.SendProxyV2.V1 && s.SendProxyV2.V2 == nil && !s.SendProxyV2.V2SSL && !s.SendProxyV2.V2SSLCN {
 			model.SendProxy = models.ServerParamsSendProxyEnabled
@@ -584,9 +826,11 @@ func (s *Server) Model() (models.Server, error) {
 	if s.SSL != nil && s.SSL.Enabled {
 		model.Ssl = models.ServerParamsSslEnabled
 
-		if s.SSL.Certificate != nil {
-			model.SslCertificate = s.SSL.Certificate.FilePath()
+		certificate, err := s.SSL.certificate()
+		if err != nil {
+			return model, err
 		}
+		model.SslCertificate = certificate
 
 		if s.SSL.CACertificate == nil {
 			model.Verify = "none"
@@ -601,6 +845,16 @@ func (s *Server) Model() (models.Server, error) {
 		if s.SSL.SNI != "" {
 			model.Sni = s.SSL.SNI
 		}
+
+		if s.SSL.CRLFile != nil {
+			model.CrlFile = s.SSL.CRLFile.FilePath()
+		}
+
+		model.Verifyhost = s.SSL.VerifyHost
+		model.Ciphers = s.SSL.Ciphers
+		model.Ciphersuites = s.SSL.Ciphersuites
+		model.Curves = s.SSL.Curves
+		model.Sigalgs = s.SSL.Sigalgs
 	}
 
 	if s.Check != nil && s.Check.Enabled {
@@ -612,6 +866,27 @@ func (s *Server) Model() (models.Server, error) {
 
 		model.Rise = s.Check.Rise
 		model.Fall = s.Check.Fall
+
+		if s.Check.SSL != nil {
+			model.CheckSsl = models.ServerParamsCheckSslDisabled
+			if *s.Check.SSL {
+				model.CheckSsl = models.ServerParamsCheckSslEnabled
+			}
+		}
+		model.CheckProto = s.Check.Proto
+		model.HealthCheckAddress = s.Check.Address
+		model.HealthCheckPort = s.Check.Port
+	}
+
+	if s.AgentCheck != nil && s.AgentCheck.Enabled {
+		model.AgentCheck = models.ServerParamsAgentCheckEnabled
+		model.AgentPort = ptr.To(s.AgentCheck.Port)
+		model.AgentAddr = s.AgentCheck.Address
+		model.AgentSend = s.AgentCheck.Send
+
+		if s.AgentCheck.Inter != nil {
+			model.AgentInter = ptr.To(s.AgentCheck.Inter.Milliseconds())
+		}
 	}
 
 	if s.Resolvers != nil {
@@ -667,22 +942,186 @@ type Check struct {
 	// This value defaults to 3 if unspecified.
 	// +optional
 	Fall *int64 `json:"fall,omitempty"`
+	// SSL forces encryption of health checks. It is required for servers which do not use SSL for traffic but for
+	// health checks, e.g. when checking a dedicated TLS port.
+	// +optional
+	SSL *bool `json:"ssl,omitempty"`
+	// Proto forces the multiplexer protocol of health checks, e.g. h2.
+	// +optional
+	Proto string `json:"proto,omitempty"`
+	// Address sends health checks to another address than the server address.
+	// +optional
+	Address string `json:"addr,omitempty"`
+	// Port sends health checks to another port than the server port.
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+}
+
+type AgentCheck struct {
+	// Enabled enables the agent check on a server.
+	Enabled bool `json:"enabled"`
+	// Port of the agent.
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Minimum=1
+	Port int64 `json:"port"`
+	// Address of the agent. Defaults to the address of the server.
+	// +optional
+	Address string `json:"addr,omitempty"`
+	// Inter sets the interval between two consecutive agent checks. If left unspecified, the delay defaults to 2000 ms.
+	// +optional
+	Inter *metav1.Duration `json:"inter,omitempty"`
+	// Send is a string sent to the agent upon connection, e.g. the name of the service.
+	// +optional
+	Send string `json:"send,omitempty"`
 }
 
 type Balance struct {
 	// Algorithm is the algorithm used to select a server when doing load balancing. This only applies when no persistence information is available, or when a connection is redispatched to another server.
-	// +kubebuilder:validation:Enum=roundrobin;static-rr;leastconn;first;source;uri;hdr;random;rdp-cookie
+	// +kubebuilder:validation:Enum=roundrobin;static-rr;leastconn;first;source;uri;url_param;hdr;random;rdp-cookie;hash
 	Algorithm string `json:"algorithm"`
+	// URI configures the uri algorithm.
+	// +optional
+	URI *BalanceURI `json:"uri,omitempty"`
+	// URLParam configures the url_param algorithm. It is required for url_param.
+	// +optional
+	URLParam *BalanceURLParam `json:"urlParam,omitempty"`
+	// Hdr configures the hdr algorithm. It is required for hdr.
+	// +optional
+	Hdr *BalanceHdr `json:"hdr,omitempty"`
+	// RdpCookie configures the rdp-cookie algorithm.
+	// +optional
+	RdpCookie *BalanceRdpCookie `json:"rdpCookie,omitempty"`
+	// Random configures the random algorithm.
+	// +optional
+	Random *BalanceRandom `json:"random,omitempty"`
+	// Hash configures the hash algorithm. It is required for hash.
+	// +optional
+	Hash *BalanceHash `json:"hash,omitempty"`
+	// HashBalanceFactor limits the load of each server to the given percentage of the average load when using
+	// consistent hashing. It requires the consistent hash type method.
+	// +kubebuilder:validation:Minimum=100
+	// +optional
+	HashBalanceFactor *int64 `json:"hashBalanceFactor,omitempty"`
+}
+
+type BalanceURI struct {
+	// Len only considers the given number of characters of the URI.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Len *int64 `json:"len,omitempty"`
+	// Depth only considers the given number of directories of the URI.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Depth *int64 `json:"depth,omitempty"`
+	// Whole includes the query string of the URI.
+	// +optional
+	Whole bool `json:"whole,omitempty"`
+	// PathOnly only considers the path of the URI, without scheme and authority.
+	// +optional
+	PathOnly bool `json:"pathOnly,omitempty"`
+}
+
+type BalanceURLParam struct {
+	// Name of the URL parameter.
+	Name string `json:"name"`
+	// CheckPost looks for the parameter in the body of POST requests, reading at most the given number of bytes.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CheckPost *int64 `json:"checkPost,omitempty"`
+	// MaxWait is the number of bytes to wait for in the body of POST requests before giving up.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxWait *int64 `json:"maxWait,omitempty"`
+}
+
+type BalanceHdr struct {
+	// Name of the HTTP header.
+	Name string `json:"name"`
+	// UseDomainOnly only hashes the domain name of the header value, e.g. of the Host header.
+	// +optional
+	UseDomainOnly bool `json:"useDomainOnly,omitempty"`
+}
+
+type BalanceRdpCookie struct {
+	// Name of the cookie. Defaults to mstshash.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+type BalanceRandom struct {
+	// Draws is the number of random draws. The least loaded server of the draws is used.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Draws *int64 `json:"draws,omitempty"`
+}
+
+type BalanceHash struct {
+	// Expression is the sample expression used to compute the hash, e.g. req.cookie(session).
+	Expression string `json:"expression"`
 }
 
 func (b *Balance) Model() (models.Balance, error) {
+	algorithm := strings.ToLower(b.Algorithm)
 	model := models.Balance{
-		Algorithm: ptr.To(b.Algorithm),
+		Algorithm: ptr.To(algorithm),
+	}
+
+	params := map[string]bool{
+		"uri":        b.URI != nil,
+		"url_param":  b.URLParam != nil,
+		"hdr":        b.Hdr != nil,
+		"rdp-cookie": b.RdpCookie != nil,
+		"random":     b.Random != nil,
+		"hash":       b.Hash != nil,
+	}
+	for name, set := range params {
+		if set && name != algorithm {
+			return model, fmt.Errorf("balance parameters of %s not allowed with algorithm %s", name, algorithm)
+		}
+	}
+
+	switch algorithm {
+	case "uri":
+		if b.URI != nil {
+			model.URILen = ptr.Deref(b.URI.Len, 0)
+			model.URIDepth = ptr.Deref(b.URI.Depth, 0)
+			model.URIWhole = b.URI.Whole
+			model.URIPathOnly = b.URI.PathOnly
+		}
+	case "url_param":
+		if b.URLParam == nil {
+			return model, fmt.Errorf("balance algorithm url_param requires a parameter name")
+		}
+		model.URLParam = b.URLParam.Name
+		model.URLParamCheckPost = ptr.Deref(b.URLParam.CheckPost, 0)
+		model.URLParamMaxWait = ptr.Deref(b.URLParam.MaxWait, 0)
+	case "hdr":
+		if b.Hdr == nil {
+			return model, fmt.Errorf("balance algorithm hdr requires a header name")
+		}
+		model.HdrName = b.Hdr.Name
+		model.HdrUseDomainOnly = b.Hdr.UseDomainOnly
+	case "rdp-cookie":
+		if b.RdpCookie != nil {
+			model.RdpCookieName = b.RdpCookie.Name
+		}
+	case "random":
+		if b.Random != nil {
+			model.RandomDraws = ptr.Deref(b.Random.Draws, 0)
+		}
+	case "hash":
+		if b.Hash == nil {
+			return model, fmt.Errorf("balance algorithm hash requires an expression")
+		}
+		model.HashExpression = b.Hash.Expression
 	}
 
 	return model, model.Validate(strfmt.Default)
 }
 
+// +kubebuilder:validation:XValidation:rule="!(has(self.certificate) && has(self.crtStore))",message="certificate and crtStore are mutually exclusive"
 type SSL struct {
 	// Enabled enables SSL deciphering on connections instantiated from this listener. A
 	// certificate is necessary. All contents in the buffers will
@@ -712,6 +1151,10 @@ type SSL struct {
 	// associated private keys.
 	// +optional
 	Certificate *SSLCertificate `json:"certificate,omitempty"`
+	// CrtStore references a certificate declared in a crt-store section instead of a certificate file. It cannot be
+	// combined with Certificate.
+	// +optional
+	CrtStore *CrtStoreReference `json:"crtStore,omitempty"`
 	// SNI parameter evaluates the sample fetch expression, converts it to a
 	// string and uses the result as the host name sent in the SNI TLS extension to
 	// the server.
@@ -721,12 +1164,129 @@ type SSL struct {
 	// list as supported on top of ALPN.
 	// +optional
 	Alpn []string `json:"alpn,omitempty"`
+	// CRLFile configures a PEM based certificate revocation list used to verify client certificates on binds or
+	// server certificates on servers.
+	// +optional
+	CRLFile *SSLCertificate `json:"crlFile,omitempty"`
+	// CAVerifyFile configures CAs which are only used to verify client certificates, they are not sent to the
+	// client in the list of acceptable CAs. It is only used on binds.
+	// +optional
+	CAVerifyFile *SSLCertificate `json:"caVerifyFile,omitempty"`
+	// VerifyHost is the host name the certificate of the peer must match. On servers the name is verified
+	// against the server certificate, on binds connections whose client certificate common name differs are
+	// rejected.
+	// +optional
+	VerifyHost string `json:"verifyHost,omitempty"`
+	// Ciphers sets the list of ciphers used for TLSv1.2 and below, in OpenSSL format.
+	// +optional
+	Ciphers string `json:"ciphers,omitempty"`
+	// Ciphersuites sets the list of cipher suites used for TLSv1.3, in OpenSSL format.
+	// +optional
+	Ciphersuites string `json:"ciphersuites,omitempty"`
+	// Curves sets the list of elliptic curves offered during the handshake, e.g. X25519:P-256.
+	// +optional
+	Curves string `json:"curves,omitempty"`
+	// Sigalgs sets the list of signature algorithms offered during the handshake, e.g. ECDSA+SHA256:RSA+SHA256.
+	// +optional
+	Sigalgs string `json:"sigalgs,omitempty"`
+	// TLSTicketKeys uses the TLS session ticket keys shared by all replicas of the instance, so clients can resume
+	// sessions on any replica. It requires tlsTicketKeys in the global configuration of the instance and is only
+	// used on binds.
+	// +optional
+	TLSTicketKeys bool `json:"tlsTicketKeys,omitempty"`
+}
+
+// certificate returns the certificate file or the crt-store certificate of the SSL options.
+func (s *SSL) certificate() (string, error) {
+	switch {
+	case s.Certificate != nil && s.CrtStore != nil:
+		return "", fmt.Errorf("certificate and crtStore are mutually exclusive")
+	case s.CrtStore != nil:
+		return s.CrtStore.String(), nil
+	case s.Certificate != nil:
+		return s.Certificate.FilePath(), nil
+	}
+
+	return "", nil
+}
+
+// TLSTicketKeysFilePath is the path of the TLS session ticket keys generated by the operator.
+const TLSTicketKeysFilePath = "/usr/local/etc/haproxy/tls-ticket.keys"
+
+// ClientCertificateHeaders forwards details of the client certificate to the backends in X-SSL-Client-* request
+// headers. Headers sent by the client with the same names are removed.
+type ClientCertificateHeaders struct {
+	// DN adds the subject distinguished name of the client certificate as X-SSL-Client-DN.
+	// +optional
+	DN bool `json:"dn,omitempty"`
+	// Serial adds the hex encoded serial number of the client certificate as X-SSL-Client-Serial.
+	// +optional
+	Serial bool `json:"serial,omitempty"`
+	// Verify adds the result of the client certificate verification as X-SSL-Client-Verify, 0 means success.
+	// +optional
+	Verify bool `json:"verify,omitempty"`
+	// SHA1 adds the hex encoded SHA1 fingerprint of the client certificate as X-SSL-Client-SHA1.
+	// +optional
+	SHA1 bool `json:"sha1,omitempty"`
+}
+
+// Model returns the rules setting the enabled headers for requests received on the named bind.
+func (c *ClientCertificateHeaders) Model(bind string) []models.HTTPRequestRule {
+	var rules []models.HTTPRequestRule
+
+	add := func(enabled bool, name, format string) {
+		if enabled {
+			rules = append(rules, models.HTTPRequestRule{
+				Type:      "set-header",
+				HdrName:   name,
+				HdrFormat: format,
+				Cond:      "if",
+				CondTest:  fmt.Sprintf("{ so_name %s }", bind),
+			})
+		}
+	}
+
+	add(c.DN, "X-SSL-Client-DN", "%{+Q}[ssl_c_s_dn]")
+	add(c.Serial, "X-SSL-Client-Serial", "%[ssl_c_serial,hex]")
+	add(c.Verify, "X-SSL-Client-Verify", "%[ssl_c_verify]")
+	add(c.SHA1, "X-SSL-Client-SHA1", "%[ssl_c_sha1,hex]")
+
+	return rules
 }
 
 type SSLCertificate struct {
 	Name      string                    `json:"name"`
 	Value     *string                   `json:"value,omitempty"`
 	ValueFrom []SSLCertificateValueFrom `json:"valueFrom,omitempty"`
+	// CertManager requests the certificate from cert-manager. The operator creates a Certificate resource and
+	// loads tls.crt, tls.key and ca.crt of the issued Secret. Renewed certificates are picked up automatically.
+	// +optional
+	CertManager *CertManagerCertificate `json:"certManager,omitempty"`
+}
+
+type CertManagerCertificate struct {
+	// IssuerRef references the issuer signing the certificate.
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+	// DNSNames is the list of DNS subject alternative names of the certificate.
+	// +kubebuilder:validation:MinItems=1
+	DNSNames []string `json:"dnsNames"`
+	// Duration is the requested lifetime of the certificate. The issuer may ignore it, cert-manager defaults to
+	// 90 days.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+type CertManagerIssuerReference struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer for an issuer in the namespace of the instance or ClusterIssuer.
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer, external issuers use their own API group.
+	// +kubebuilder:default=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 func (s *SSLCertificate) FilePath() string {
@@ -767,6 +1327,22 @@ type CertificateListElement struct {
 	// OcspFile you can save the OCSP response to a file so that HAProxy loads it during startup.
 	// +optional
 	OcspFile *OcspFile `json:"ocsp_file,omitempty"`
+	// ACME issues the certificate using an ACME provider of the instance. Until the certificate is issued, a
+	// temporary self-signed certificate is served. The Certificate must not have values.
+	// +optional
+	ACME *ACMECertificate `json:"acme,omitempty"`
+}
+
+type ACMECertificate struct {
+	// Provider is the name of the ACME provider of the instance issuing the certificate.
+	Provider string `json:"provider"`
+	// Domains is the list of domains of the certificate. The first domain is used as common name.
+	// +kubebuilder:validation:MinItems=1
+	Domains []string `json:"domains"`
+}
+
+func (a *ACMECertificate) String() string {
+	return fmt.Sprintf("acme %s domains %s", a.Provider, strings.Join(a.Domains, ","))
 }
 
 type OcspFile struct {
@@ -787,6 +1363,22 @@ type CertificateList struct {
 	Elements []CertificateListElement `json:"elements,omitempty"`
 	// LabelSelector to select multiple backend certificates
 	LabelSelector *metav1.LabelSelector `json:"selector,omitempty"`
+	// SecretSelector selects Secrets of type kubernetes.io/tls whose certificates are added to the list. The SNI
+	// filters are derived from the DNS subject alternative names of the certificates, or the common name of
+	// certificates without them.
+	// +optional
+	SecretSelector *CertificateSecretSelector `json:"secretSelector,omitempty"`
+}
+
+type CertificateSecretSelector struct {
+	// LabelSelector selects the Secrets by their labels.
+	LabelSelector metav1.LabelSelector `json:"selector"`
+	// Namespaces in which the Secrets are selected. Defaults to the namespace of the instance.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Alpn enables the TLS ALPN extension and advertises the specified protocol list for the selected certificates.
+	// +optional
+	Alpn []string `json:"alpn,omitempty"`
 }
 
 func (r *CertificateList) FilePath() string {
@@ -1051,8 +1643,42 @@ type Status struct {
 	// Error shows the actual error message if Phase is 'Error'.
 	// +optional
 	Error string `json:"error,omitempty"`
+	// Certificates lists the certificates loaded for the object.
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+	// Conditions represent the latest available observations of the object. The CertificateWarning condition is
+	// true if a certificate expires soon or does not match its private key.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// CertificateStatus describes a loaded certificate. Bundles are described by their first certificate.
+type CertificateStatus struct {
+	// Name of the certificate.
+	Name string `json:"name"`
+	// Subject is the distinguished name of the subject.
+	Subject string `json:"subject"`
+	// Issuer is the distinguished name of the issuer.
+	Issuer string `json:"issuer"`
+	// DNSNames are the DNS subject alternative names.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// NotAfter is the time the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+	// KeyMismatch is true if the private key loaded with the certificate does not belong to it.
+	// +optional
+	KeyMismatch bool `json:"keyMismatch,omitempty"`
+	// Pending is true while a temporary self-signed certificate is served until the certificate is issued.
+	// +optional
+	Pending bool `json:"pending,omitempty"`
+}
+
+const (
+	// ConditionCertificateWarning is true if a loaded certificate is pending, expires soon or does not match its
+	// private key.
+	ConditionCertificateWarning = "CertificateWarning"
+)
+
 // StatusPhase is a label for the phase of an object at the current time.
 type StatusPhase string
 
@@ -1269,3 +1895,617 @@ type HTTPChk struct {
 	// +kubebuilder:validation:Enum=HEAD;PUT;POST;GET;TRACE;PATCH;DELETE;CONNECT;OPTIONS;
 	Method string `json:"method,omitempty"`
 }
+
+type HTTPCheckRule struct {
+	// Type of the http-check rule.
+	// +kubebuilder:validation:Enum=connect;send;expect;send-state;disable-on-404
+	Type string `json:"type"`
+	// Connect configures the connection of a connect rule.
+	// +optional
+	Connect *CheckConnect `json:"connect,omitempty"`
+	// Method of the request sent by a send rule.
+	// +kubebuilder:validation:Enum=HEAD;PUT;POST;GET;TRACE;PATCH;DELETE;CONNECT;OPTIONS
+	// +optional
+	Method string `json:"method,omitempty"`
+	// URI of the request sent by a send rule.
+	// +optional
+	URI string `json:"uri,omitempty"`
+	// Version of the request sent by a send rule, e.g. HTTP/1.1.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Headers added to the request sent by a send rule.
+	// +optional
+	Headers []CheckHeader `json:"headers,omitempty"`
+	// Body of the request sent by a send rule.
+	// +optional
+	Body string `json:"body,omitempty"`
+	// Expect configures the response matched by an expect rule.
+	// +optional
+	Expect *CheckExpect `json:"expect,omitempty"`
+	// Comment is reported in the logs if the rule fails.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+func (h *HTTPCheckRule) Model() (models.HTTPCheck, error) {
+	model := models.HTTPCheck{
+		Type:         h.Type,
+		Method:       h.Method,
+		URI:          h.URI,
+		Version:      h.Version,
+		Body:         h.Body,
+		CheckComment: h.Comment,
+	}
+
+	for _, header := range h.Headers {
+		model.CheckHeaders = append(model.CheckHeaders, &models.ReturnHeader{
+			Name: ptr.To(header.Name),
+			Fmt:  ptr.To(header.Value),
+		})
+	}
+
+	if h.Connect != nil {
+		model.Addr = h.Connect.Address
+		model.Port = h.Connect.Port
+		model.Ssl = h.Connect.SSL
+		model.Sni = h.Connect.SNI
+		model.Alpn = strings.Join(h.Connect.Alpn, ",")
+		model.Proto = h.Connect.Proto
+	}
+
+	if h.Expect != nil {
+		if !slices.Contains([]string{"status", "rstatus", "string", "rstring"}, h.Expect.Match) {
+			return model, fmt.Errorf("http-check expect match %s not supported", h.Expect.Match)
+		}
+		model.Match = h.Expect.Match
+		model.Pattern = h.Expect.Pattern
+		model.ExclamationMark = h.Expect.Negate
+	}
+
+	return model, model.Validate(strfmt.Default)
+}
+
+type TCPCheckRule struct {
+	// Action of the tcp-check rule.
+	// +kubebuilder:validation:Enum=connect;send;send-binary;expect
+	Action string `json:"action"`
+	// Connect configures the connection of a connect rule.
+	// +optional
+	Connect *CheckConnect `json:"connect,omitempty"`
+	// Data sent by a send rule. For send-binary it is a hexadecimal string.
+	// +optional
+	Data string `json:"data,omitempty"`
+	// Expect configures the response matched by an expect rule.
+	// +optional
+	Expect *CheckExpect `json:"expect,omitempty"`
+	// Comment is reported in the logs if the rule fails.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+func (t *TCPCheckRule) Model() (models.TCPCheck, error) {
+	model := models.TCPCheck{
+		Action:       t.Action,
+		CheckComment: t.Comment,
+	}
+
+	switch t.Action {
+	case "send":
+		model.Data = t.Data
+	case "send-binary":
+		model.HexString = t.Data
+	}
+
+	if t.Connect != nil {
+		model.Addr = t.Connect.Address
+		model.Port = t.Connect.Port
+		model.Ssl = t.Connect.SSL
+		model.Sni = t.Connect.SNI
+		model.Alpn = strings.Join(t.Connect.Alpn, ",")
+		model.Proto = t.Connect.Proto
+	}
+
+	if t.Expect != nil {
+		if !slices.Contains([]string{"string", "rstring", "binary", "rbinary"}, t.Expect.Match) {
+			return model, fmt.Errorf("tcp-check expect match %s not supported", t.Expect.Match)
+		}
+		model.Match = t.Expect.Match
+		model.Pattern = t.Expect.Pattern
+		model.ExclamationMark = t.Expect.Negate
+	}
+
+	return model, model.Validate(strfmt.Default)
+}
+
+type CheckConnect struct {
+	// Address of the connection. Defaults to the address of the server.
+	// +optional
+	Address string `json:"addr,omitempty"`
+	// Port of the connection. Defaults to the check port of the server.
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+	// SSL opens a ciphered connection.
+	// +optional
+	SSL bool `json:"ssl,omitempty"`
+	// SNI sent on ciphered connections.
+	// +optional
+	SNI string `json:"sni,omitempty"`
+	// Alpn protocols advertised on ciphered connections.
+	// +optional
+	Alpn []string `json:"alpn,omitempty"`
+	// Proto forces the multiplexer protocol of the connection, e.g. h2.
+	// +optional
+	Proto string `json:"proto,omitempty"`
+}
+
+type CheckHeader struct {
+	// Name of the header.
+	Name string `json:"name"`
+	// Value of the header. It is a log-format string.
+	Value string `json:"value"`
+}
+
+type CheckExpect struct {
+	// Match is the type of the pattern: status and rstatus match the HTTP status code, string and rstring the
+	// response body or the received data, binary and rbinary the received data as hexadecimal string.
+	// +kubebuilder:validation:Enum=status;rstatus;string;rstring;binary;rbinary
+	Match string `json:"match"`
+	// Pattern to match, e.g. a status range like 200-399 or a regular expression for rstatus, rstring and rbinary.
+	Pattern string `json:"pattern"`
+	// Negate inverts the result of the match.
+	// +optional
+	Negate bool `json:"negate,omitempty"`
+}
+
+type ProxyLogging struct {
+	// LogFormat replaces the log format of HTTPLog and TCPLog for the access logs of the proxy.
+	// +optional
+	LogFormat *LogFormat `json:"logFormat,omitempty"`
+	// LogFormatSD is the structured-data part of RFC 5424 log messages. It uses the log-format syntax and is used
+	// as is in the configuration.
+	// +optional
+	LogFormatSD string `json:"logFormatSD,omitempty"`
+	// ErrorLogFormat is the format of the logs of connection errors, e.g. failed TLS handshakes. It uses the
+	// log-format syntax and is used as is in the configuration.
+	// +optional
+	ErrorLogFormat string `json:"errorLogFormat,omitempty"`
+	// LogTargets send the logs of the proxy to other targets. They replace the log targets inherited from the
+	// defaults section.
+	// +optional
+	LogTargets []LogTarget `json:"logTargets,omitempty"`
+	// Captures capture request and response headers for logging. They are logged by httpLog and added to the
+	// fields of logFormat, which requires mode http.
+	// +optional
+	Captures *Captures `json:"captures,omitempty"`
+	// UniqueID generates a unique ID for each request, which is added to the fields of logFormat.
+	// +optional
+	UniqueID *UniqueID `json:"uniqueID,omitempty"`
+}
+
+type UniqueID struct {
+	// Preset 'request-id' uses the ID of the request header and generates a UUID if the request does not contain
+	// exactly one header with up to 128 letters, digits, '.', '_', ':' or '-'. The header is forwarded to the servers,
+	// so requests can be correlated across services.
+	// +kubebuilder:validation:Enum=request-id
+	// +optional
+	Preset string `json:"preset,omitempty"`
+	// Format is the unique-id-format, e.g. "%{+X}o\ %ci:%cp_%fi:%fp_%Ts_%rt:%pid". It is used as is in the
+	// configuration, so spaces must be escaped or the string quoted.
+	// +optional
+	Format string `json:"format,omitempty"`
+	// Header is the name of the request header containing the ID. It defaults to X-Request-ID with the
+	// request-id preset, otherwise the header is only added if it is set.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	// +optional
+	Header string `json:"header,omitempty"`
+}
+
+// defaultUniqueIDHeader is the request header of the request-id preset.
+const defaultUniqueIDHeader = "X-Request-ID"
+
+// uniqueIDPattern matches the IDs of the request-id preset which are kept, other IDs are replaced, so clients
+// cannot inject arbitrary data into the logs and the requests of the servers.
+const uniqueIDPattern = `^[A-Za-z0-9._:-]{1,128}$`
+
+func (u *UniqueID) header() string {
+	if u.Header == "" && u.Preset == "request-id" {
+		return defaultUniqueIDHeader
+	}
+
+	return u.Header
+}
+
+// addToModel sets the unique ID format and header. The request-id preset reads the ID from its header, which is
+// set before by the rule of addToParser.
+func (u *UniqueID) addToModel(frontend *models.FrontendBase) error {
+	if (u.Preset == "") == (u.Format == "") {
+		return fmt.Errorf("unique ID requires either a preset or a format")
+	}
+
+	if u.Preset == "request-id" {
+		frontend.UniqueIDFormat = fmt.Sprintf("%%[req.hdr(%s)]", u.header())
+		return nil
+	}
+
+	frontend.UniqueIDFormat = u.Format
+	frontend.UniqueIDHeader = u.Header
+
+	return nil
+}
+
+// addToParser generates the request header of the request-id preset if it is absent, repeated or does not match
+// uniqueIDPattern, before all other http-request rules.
+func (u *UniqueID) addToParser(p parser.Parser, sectionType parser.Section, sectionName string) error {
+	if u.Preset != "request-id" {
+		return nil
+	}
+
+	rule := models.HTTPRequestRule{
+		Type:      "set-header",
+		HdrName:   u.header(),
+		HdrFormat: "%[uuid()]",
+		Cond:      "unless",
+		CondTest:  fmt.Sprintf("{ req.fhdr_cnt(%s) eq 1 } { req.fhdr(%s) -m reg %s }", u.header(), u.header(), uniqueIDPattern),
+	}
+	data, err := configuration.SerializeHTTPRequestRule(rule, &options.ConfigurationOptions{})
+	if err != nil {
+		return err
+	}
+
+	return p.Insert(sectionType, sectionName, "http-request", data, 0)
+}
+
+type Captures struct {
+	// RequestHeaders are captured from the requests.
+	// +optional
+	RequestHeaders []HeaderCapture `json:"requestHeaders,omitempty"`
+	// ResponseHeaders are captured from the responses.
+	// +optional
+	ResponseHeaders []HeaderCapture `json:"responseHeaders,omitempty"`
+}
+
+type HeaderCapture struct {
+	// Name of the header.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`
+	Name string `json:"name"`
+	// Length is the maximal number of characters captured from the header value.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=64
+	// +optional
+	Length int64 `json:"length,omitempty"`
+}
+
+// logFields returns a log field for each captured header. The values are escaped, so they are valid JSON strings.
+func (c *Captures) logFields() []LogField {
+	if c == nil {
+		return nil
+	}
+
+	var fields []LogField
+	for idx, header := range c.RequestHeaders {
+		fields = append(fields, LogField{
+			Name:       "request_" + header.fieldName(),
+			Expression: fmt.Sprintf("%%[capture.req.hdr(%d),json(utf8s)]", idx),
+		})
+	}
+	for idx, header := range c.ResponseHeaders {
+		fields = append(fields, LogField{
+			Name:       "response_" + header.fieldName(),
+			Expression: fmt.Sprintf("%%[capture.res.hdr(%d),json(utf8s)]", idx),
+		})
+	}
+
+	return fields
+}
+
+// addToParser captures the request headers before all other http-request rules, so the headers of denied or
+// redirected requests are logged too. The slots of response headers are declared, as http-response rules can only
+// capture into declared slots.
+func (c *Captures) addToParser(p parser.Parser, sectionType parser.Section, sectionName string) error {
+	configOpts := &options.ConfigurationOptions{}
+
+	for idx, header := range c.RequestHeaders {
+		rule := models.HTTPRequestRule{
+			Type:          "capture",
+			CaptureSample: fmt.Sprintf("req.hdr(%s)", header.Name),
+			CaptureLen:    header.length(),
+		}
+		data, err := configuration.SerializeHTTPRequestRule(rule, configOpts)
+		if err != nil {
+			return err
+		}
+		if err := p.Insert(sectionType, sectionName, "http-request", data, idx); err != nil {
+			return err
+		}
+	}
+
+	for idx, header := range c.ResponseHeaders {
+		if err := p.Insert(sectionType, sectionName, "declare capture", types.DeclareCapture{Type: "response", Length: header.length()}); err != nil {
+			return err
+		}
+
+		rule := models.HTTPResponseRule{
+			Type:          "capture",
+			CaptureSample: fmt.Sprintf("res.hdr(%s)", header.Name),
+			CaptureID:     ptr.To(int64(idx)),
+		}
+		data, err := configuration.SerializeHTTPResponseRule(rule, configOpts)
+		if err != nil {
+			return err
+		}
+		if err := p.Insert(sectionType, sectionName, "http-response", data, idx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HeaderCapture) length() int64 {
+	if h.Length > 0 {
+		return h.Length
+	}
+
+	return 64
+}
+
+// fieldName returns the header name in lower snake case, e.g. user_agent for User-Agent.
+func (h *HeaderCapture) fieldName() string {
+	return strings.ToLower(strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, h.Name))
+}
+
+// addToModel sets the log formats of the proxy in its model.
+func (l *ProxyLogging) addToModel(frontend *models.FrontendBase, httpLog, tcpLog bool) error {
+	if l.LogFormat != nil {
+		if httpLog || tcpLog {
+			return fmt.Errorf("logFormat cannot be combined with httpLog or tcpLog")
+		}
+
+		fields := l.Captures.logFields()
+		if l.UniqueID != nil {
+			fields = append([]LogField{{Name: "unique_id", Value: "unique-id"}}, fields...)
+		}
+
+		format, err := l.LogFormat.String(fields...)
+		if err != nil {
+			return err
+		}
+		frontend.LogFormat = format
+	}
+
+	frontend.LogFormatSd = l.LogFormatSD
+	frontend.ErrorLogFormat = l.ErrorLogFormat
+
+	if l.UniqueID != nil {
+		if err := l.UniqueID.addToModel(frontend); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addToParser adds the log targets, the unique ID rules and the captures of the proxy.
+func (l *ProxyLogging) addToParser(p parser.Parser, sectionType parser.Section, sectionName, mode string) error {
+	if l.UniqueID != nil && l.UniqueID.header() != "" {
+		if mode != "http" {
+			return fmt.Errorf("unique ID headers require mode http")
+		}
+		if err := l.UniqueID.addToParser(p, sectionType, sectionName); err != nil {
+			return err
+		}
+	}
+
+	if l.Captures != nil {
+		if mode != "http" {
+			return fmt.Errorf("captures require mode http")
+		}
+		if err := l.Captures.addToParser(p, sectionType, sectionName); err != nil {
+			return err
+		}
+	}
+
+	for _, target := range l.LogTargets {
+		model, err := target.Model()
+		if err != nil {
+			return err
+		}
+		if err := p.Insert(sectionType, sectionName, "log", configuration.SerializeLogTarget(model)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type LogTarget struct {
+	// Address can be a filesystem path to a UNIX domain socket or a remote syslog target (IPv4/IPv6 address optionally followed by a colon and a UDP port).
+	// +kubebuilder:validation:Pattern=^[^\s]+$
+	Address string `json:"address"`
+	// Facility must be one of the 24 standard syslog facilities.
+	// +kubebuilder:validation:Enum=kern;user;mail;daemon;auth;syslog;lpr;news;uucp;cron;auth2;ftp;ntp;audit;alert;cron2;local0;local1;local2;local3;local4;local5;local6;local7
+	// +kubebuilder:default=local0
+	Facility string `json:"facility,omitempty"`
+	// Level can be specified to filter outgoing messages. By default, all messages are sent.
+	// +kubebuilder:validation:Enum=emerg;alert;crit;err;warning;notice;info;debug
+	// +optional
+	Level string `json:"level,omitempty"`
+	// Format is the log format used when generating syslog messages.
+	// +kubebuilder:validation:Enum=rfc3164;rfc5424;short;raw
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+func (l *LogTarget) Model() (models.LogTarget, error) {
+	model := models.LogTarget{
+		Address:  l.Address,
+		Facility: l.Facility,
+		Level:    l.Level,
+		Format:   l.Format,
+	}
+
+	return model, model.Validate(strfmt.Default)
+}
+
+type LogFormat struct {
+	// Preset selects a predefined format. 'json' logs a JSON object with the client, the timers and the status,
+	// which is extended by the captured headers and Fields. 'clf' logs the Common Log Format followed by the HAProxy
+	// specific fields.
+	// +kubebuilder:validation:Enum=json;clf
+	// +optional
+	Preset string `json:"preset,omitempty"`
+	// Raw is a log-format string, e.g. "%ci:%cp\ [%tr]\ %ft\ %b/%s\ %ST". It is used as is in the configuration, so
+	// spaces must be escaped or the string quoted.
+	// +optional
+	Raw string `json:"raw,omitempty"`
+	// Fields are logged as JSON object. They are appended to the fields of the json preset, the unique ID and the
+	// captured headers.
+	// +optional
+	Fields []LogField `json:"fields,omitempty"`
+}
+
+// clfLogFormat is the log format of 'option httplog clf'.
+const clfLogFormat = `%{+Q}o %{-Q}ci - - [%trg] %r %ST %B "" "" %cp %ms %ft %b %s %TR %Tw %Tc %Tr %Ta %tsc %ac %fc %bc %sc %rc %sq %bq %CC %CS %hrl %hsl`
+
+// jsonLogFields are the fields of the json preset.
+var jsonLogFields = []LogField{
+	{Name: "client_ip", Value: "client-ip"},
+	{Name: "client_port", Value: "client-port"},
+	{Name: "accept_date", Value: "accept-date"},
+	{Name: "frontend", Value: "frontend"},
+	{Name: "backend", Value: "backend"},
+	{Name: "server", Value: "server"},
+	{Name: "method", Value: "method"},
+	{Name: "uri", Value: "uri"},
+	{Name: "version", Value: "version"},
+	{Name: "status", Value: "status"},
+	{Name: "bytes_read", Value: "bytes-read"},
+	{Name: "bytes_uploaded", Value: "bytes-uploaded"},
+	{Name: "request_time", Value: "request-time"},
+	{Name: "queue_time", Value: "queue-time"},
+	{Name: "connect_time", Value: "connect-time"},
+	{Name: "response_time", Value: "response-time"},
+	{Name: "total_time", Value: "total-time"},
+	{Name: "termination_state", Value: "termination-state"},
+	{Name: "retries", Value: "retries"},
+}
+
+// logFieldValues maps the predefined values of log fields to their log-format variables. Values controlled by the
+// client are read with sample fetches and escaped, so they are valid JSON strings.
+var logFieldValues = map[string]string{
+	"client-ip":         "%ci",
+	"client-port":       "%cp",
+	"frontend-ip":       "%fi",
+	"frontend-port":     "%fp",
+	"accept-date":       "%tr",
+	"timestamp":         "%Ts",
+	"frontend":          "%ft",
+	"backend":           "%b",
+	"server":            "%s",
+	"server-ip":         "%si",
+	"server-port":       "%sp",
+	"method":            "%HM",
+	"path":              "%[path,json(utf8s)]",
+	"query":             "%[query,json(utf8s)]",
+	"uri":               "%[capture.req.uri,json(utf8s)]",
+	"version":           "%HV",
+	"request-line":      "%[capture.req.method,json(utf8s)] %[capture.req.uri,json(utf8s)] %[capture.req.ver,json(utf8s)]",
+	"status":            "%ST",
+	"bytes-read":        "%B",
+	"bytes-uploaded":    "%U",
+	"handshake-time":    "%Th",
+	"idle-time":         "%Ti",
+	"request-time":      "%TR",
+	"queue-time":        "%Tw",
+	"connect-time":      "%Tc",
+	"response-time":     "%Tr",
+	"active-time":       "%Ta",
+	"total-time":        "%Tt",
+	"termination-state": "%tsc",
+	"retries":           "%rc",
+	"unique-id":         "%[unique-id,json(utf8s)]",
+	"ssl-version":       "%sslv",
+	"ssl-cipher":        "%sslc",
+}
+
+type LogField struct {
+	// Name is the key of the field in the JSON object.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+$`
+	Name string `json:"name"`
+	// Value selects a predefined value of the log field.
+	// +kubebuilder:validation:Enum=client-ip;client-port;frontend-ip;frontend-port;accept-date;timestamp;frontend;backend;server;server-ip;server-port;method;path;query;uri;version;request-line;status;bytes-read;bytes-uploaded;handshake-time;idle-time;request-time;queue-time;connect-time;response-time;active-time;total-time;termination-state;retries;unique-id;ssl-version;ssl-cipher
+	// +optional
+	Value string `json:"value,omitempty"`
+	// Expression is a log-format expression, e.g. %[req.hdr(host)] or %[capture.req.hdr(0)].
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
+func (l *LogField) expression() (string, error) {
+	if (l.Value == "") == (l.Expression == "") {
+		return "", fmt.Errorf("log field %s requires either a value or an expression", l.Name)
+	}
+	if l.Expression != "" {
+		return l.Expression, nil
+	}
+
+	variable, ok := logFieldValues[l.Value]
+	if !ok {
+		return "", fmt.Errorf("log field %s has unknown value %s", l.Name, l.Value)
+	}
+
+	return variable, nil
+}
+
+// String returns the log-format string. The generated fields, e.g. of captured headers, are added to formats built
+// from the json preset or fields. Formats built from a preset or fields are single quoted, so they can contain
+// spaces and double quotes.
+func (l *LogFormat) String(generated ...LogField) (string, error) {
+	if l.Raw != "" {
+		if l.Preset != "" || len(l.Fields) > 0 {
+			return "", fmt.Errorf("raw log format cannot be combined with a preset or fields")
+		}
+		return l.Raw, nil
+	}
+
+	if l.Preset == "clf" {
+		if len(l.Fields) > 0 {
+			return "", fmt.Errorf("log fields cannot be combined with the clf preset")
+		}
+		return quoteLogFormat(clfLogFormat), nil
+	}
+
+	var fields []LogField
+	if l.Preset == "json" {
+		fields = slices.Clone(jsonLogFields)
+	}
+	if l.Preset == "json" || len(l.Fields) > 0 {
+		fields = append(append(fields, generated...), l.Fields...)
+	}
+	if len(fields) == 0 {
+		return "", fmt.Errorf("log format requires a preset, a raw format or fields")
+	}
+
+	items := make([]string, 0, len(fields))
+	for _, field := range fields {
+		expression, err := field.expression()
+		if err != nil {
+			return "", err
+		}
+		items = append(items, fmt.Sprintf(`"%s":"%s"`, field.Name, expression))
+	}
+
+	return quoteLogFormat("{" + strings.Join(items, ",") + "}"), nil
+}
+
+// quoteLogFormat single quotes a log format. Single quotes within the format are closed, escaped and reopened.
+func quoteLogFormat(format string) string {
+	return "'" + strings.ReplaceAll(format, "'", `'\''`) + "'"
+}
@@ -0,0 +1,144 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/client-native/v6/config-parser"
+	"github.com/haproxytech/client-native/v6/configuration"
+	"github.com/haproxytech/client-native/v6/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CrtStoreSpec defines the desired state of CrtStore
+type CrtStoreSpec struct {
+	// Certificates loaded by the crt-store. Binds and servers reference them by alias using a CrtStoreReference.
+	// +kubebuilder:validation:MinItems=1
+	Certificates []CrtStoreCertificate `json:"certificates"`
+}
+
+type CrtStoreCertificate struct {
+	// Alias is the name used to reference the certificate in binds and servers. Defaults to the certificate name.
+	// +kubebuilder:validation:Pattern="^[A-Za-z0-9-_.]+$"
+	// +optional
+	Alias string `json:"alias,omitempty"`
+	// Certificate configures a PEM based certificate file. It contains the private key too if Key is not set.
+	Certificate SSLCertificate `json:"certificate"`
+	// Key configures a PEM based private key file which is loaded separately from the certificate.
+	// +optional
+	Key *SSLCertificate `json:"key,omitempty"`
+	// OcspFile you can save the OCSP response to a file so that HAProxy loads it during startup.
+	// +optional
+	OcspFile *OcspFile `json:"ocspFile,omitempty"`
+	// OcspUpdate enables automatic OCSP response update of the certificate.
+	// +optional
+	OcspUpdate *bool `json:"ocspUpdate,omitempty"`
+}
+
+func (c *CrtStoreCertificate) AliasName() string {
+	if c.Alias != "" {
+		return c.Alias
+	}
+
+	return strings.TrimSuffix(c.Certificate.Name, ".crt")
+}
+
+func (c *CrtStoreCertificate) KeyFilePath() string {
+	if c.Key == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("/usr/local/etc/haproxy/%s.key", strings.TrimSuffix(c.Key.Name, ".key"))
+}
+
+type CrtStoreReference struct {
+	// Name of the CrtStore
+	Name string `json:"name"`
+	// Certificate is the alias of the certificate in the crt-store.
+	Certificate string `json:"certificate"`
+}
+
+func (c *CrtStoreReference) String() string {
+	return fmt.Sprintf("@%s/%s", c.Name, c.Certificate)
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name=Phase,type=string,JSONPath=`.status.phase`
+
+// CrtStore is the Schema for the CrtStore API. It is rendered as a crt-store section, which requires HAProxy 3.0 or later.
+type CrtStore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CrtStoreSpec `json:"spec,omitempty"`
+	Status Status       `json:"status,omitempty"`
+}
+
+var _ Object = &CrtStore{}
+
+func (c *CrtStore) SetStatus(status Status) {
+	c.Status = status
+}
+
+func (c *CrtStore) GetStatus() Status {
+	return c.Status
+}
+
+func (c *CrtStore) Model() (models.CrtStore, error) {
+	model := models.CrtStore{
+		Name: c.Name,
+	}
+
+	for _, certificate := range c.Spec.Certificates {
+		load := &models.CrtLoad{
+			Certificate: certificate.Certificate.FilePath(),
+			Alias:       certificate.AliasName(),
+			Key:         certificate.KeyFilePath(),
+		}
+
+		if certificate.OcspFile != nil {
+			load.Ocsp = certificate.OcspFile.FilePath()
+		}
+
+		if certificate.OcspUpdate != nil {
+			load.OcspUpdate = models.CrtLoadOcspUpdateDisabled
+			if *certificate.OcspUpdate {
+				load.OcspUpdate = models.CrtLoadOcspUpdateEnabled
+			}
+		}
+
+		model.Loads = append(model.Loads, load)
+	}
+
+	return model, model.Validate(strfmt.Default)
+}
+
+func (c *CrtStore) AddToParser(p parser.Parser) error {
+	err := p.SectionsCreate(parser.CrtStore, c.Name)
+	if err != nil {
+		return err
+	}
+
+	var store models.CrtStore
+	store, err = c.Model()
+	if err != nil {
+		return err
+	}
+
+	return configuration.SerializeCrtStore(p, &store)
+}
+
+//+kubebuilder:object:root=true
+
+// CrtStoreList contains a list of CrtStore
+type CrtStoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CrtStore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CrtStore{}, &CrtStoreList{})
+}
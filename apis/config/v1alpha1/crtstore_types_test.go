@@ -0,0 +1,82 @@
+package v1alpha1_test
+
+import (
+	parser "github.com/haproxytech/client-native/v6/config-parser"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("CrtStore", Label("type"), func() {
+	Context("AddToParser", func() {
+		var p parser.Parser
+		BeforeEach(func() {
+			var err error
+			p, err = parser.New()
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+		It("should create crt-store", func() {
+			crtStore := &configv1alpha1.CrtStore{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec: configv1alpha1.CrtStoreSpec{
+					Certificates: []configv1alpha1.CrtStoreCertificate{
+						{
+							Alias:       "site",
+							Certificate: configv1alpha1.SSLCertificate{Name: "site-cert"},
+							Key:         &configv1alpha1.SSLCertificate{Name: "site-key"},
+							OcspUpdate:  ptr.To(true),
+						},
+					},
+				},
+			}
+			Ω(crtStore.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("crt-store web\n"))
+			Ω(p.String()).Should(ContainSubstring("load crt /usr/local/etc/haproxy/site-cert.crt"))
+			Ω(p.String()).Should(ContainSubstring("key /usr/local/etc/haproxy/site-key.key"))
+			Ω(p.String()).Should(ContainSubstring("alias site"))
+			Ω(p.String()).Should(ContainSubstring("ocsp-update on"))
+		})
+		It("should default alias to certificate name", func() {
+			certificate := configv1alpha1.CrtStoreCertificate{
+				Certificate: configv1alpha1.SSLCertificate{Name: "site.crt"},
+			}
+			Ω(certificate.AliasName()).Should(Equal("site"))
+			Ω(certificate.KeyFilePath()).Should(BeEmpty())
+		})
+		It("should reference crt-store certificate from bind", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					Binds: []configv1alpha1.Bind{
+						{
+							Name:    "https",
+							Address: "*",
+							Port:    443,
+							SSL: &configv1alpha1.SSL{
+								Enabled:  true,
+								CrtStore: &configv1alpha1.CrtStoreReference{Name: "web", Certificate: "site"},
+							},
+						},
+					},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("crt @web/site"))
+		})
+		It("should reject a crt-store certificate combined with a certificate file", func() {
+			ssl := &configv1alpha1.SSL{
+				Enabled:     true,
+				Certificate: &configv1alpha1.SSLCertificate{Name: "site.crt"},
+				CrtStore:    &configv1alpha1.CrtStoreReference{Name: "web", Certificate: "site"},
+			}
+
+			bind := configv1alpha1.Bind{Name: "https", Address: "*", Port: 443, SSL: ssl}
+			Ω(bind.Model()).Error().Should(MatchError("certificate and crtStore are mutually exclusive"))
+
+			server := configv1alpha1.Server{Name: "web", Address: "10.0.0.1", Port: 443, ServerParams: configv1alpha1.ServerParams{SSL: ssl}}
+			Ω(server.Model()).Error().Should(MatchError("certificate and crtStore are mutually exclusive"))
+		})
+	})
+})
@@ -0,0 +1,188 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	parser "github.com/haproxytech/client-native/v6/config-parser"
+	configparseropts "github.com/haproxytech/client-native/v6/config-parser/options"
+	"github.com/haproxytech/client-native/v6/configuration"
+	"github.com/haproxytech/client-native/v6/configuration/options"
+	"github.com/haproxytech/client-native/v6/models"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultsSpec defines the desired state of Defaults
+type DefaultsSpec struct {
+	// Mode can be either 'tcp' or 'http'. In TCP mode it is a layer 4 proxy. In HTTP mode it is a layer 7 proxy.
+	// +kubebuilder:validation:Enum=http;tcp
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// Timeouts: check, client, client-fin, connect, http-keep-alive, http-request, queue, server, server-fin, tunnel.
+	// The timeout value specified in milliseconds by default, but can be in any other unit if the number is suffixed by the unit.
+	// More info: https://cbonte.github.io/haproxy-dconv/2.6/configuration.html
+	// +optional
+	Timeouts map[string]metav1.Duration `json:"timeouts,omitempty"`
+	// ErrorFiles custom error files to be used
+	// +optional
+	ErrorFiles []*ErrorFile `json:"errorFiles,omitempty"`
+	// Logging is used to configure default logging for all proxies referencing this defaults section.
+	// +optional
+	Logging *DefaultsLogging `json:"logging,omitempty"`
+	// AdditionalParameters can be used to specify any further configuration statements which are not covered in this section explicitly.
+	// +optional
+	AdditionalParameters string `json:"additionalParameters,omitempty"`
+}
+
+type DefaultsLogging struct {
+	// Enabled will enable logs for all proxies referencing this defaults section
+	Enabled bool `json:"enabled"`
+	// HTTPLog enables HTTP log format which is the most complete and the best suited for HTTP proxies. It provides
+	// the same level of information as the TCP format with additional features which
+	// are specific to the HTTP protocol.
+	// +optional
+	HTTPLog *bool `json:"httpLog,omitempty"`
+	// TCPLog enables advanced logging of TCP connections with session state and timers. By default, the log output format
+	// is very poor, as it only contains the source and destination addresses, and the instance name.
+	// +optional
+	TCPLog *bool `json:"tcpLog,omitempty"`
+}
+
+func (l *DefaultsLogging) Model() (models.LogTarget, error) {
+	logTarget := models.LogTarget{
+		Global: l.Enabled,
+	}
+
+	return logTarget, logTarget.Validate(strfmt.Default)
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name=Mode,type=string,JSONPath=`.spec.mode`
+//+kubebuilder:printcolumn:name=Phase,type=string,JSONPath=`.status.phase`
+
+// Defaults is the Schema for the Defaults API. It is rendered as a named defaults section which can be
+// referenced by frontends, backends and listens.
+type Defaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DefaultsSpec `json:"spec,omitempty"`
+	Status Status       `json:"status,omitempty"`
+}
+
+var _ Object = &Defaults{}
+
+func (d *Defaults) SetStatus(status Status) {
+	d.Status = status
+}
+
+func (d *Defaults) GetStatus() Status {
+	return d.Status
+}
+
+func (d *Defaults) Model() (models.Defaults, error) {
+	model := models.Defaults{}
+
+	if d.Spec.AdditionalParameters != "" {
+		str := strings.ReplaceAll(fmt.Sprintf("%s %s\n%s", parser.Defaults, d.Name, d.Spec.AdditionalParameters), "\n", "\n  ")
+		p, err := parser.New(configparseropts.String(str))
+		if err != nil {
+			return model, err
+		}
+		if err = configuration.ParseSection(&model, parser.Defaults, d.Name, p); err != nil {
+			return model, err
+		}
+	}
+
+	model.Name = d.Name
+	model.Mode = d.Spec.Mode
+
+	for name, timeout := range d.Spec.Timeouts {
+		switch name {
+		case "check":
+			model.CheckTimeout = ptr.To(timeout.Milliseconds())
+		case "client":
+			model.ClientTimeout = ptr.To(timeout.Milliseconds())
+		case "client-fin":
+			model.ClientFinTimeout = ptr.To(timeout.Milliseconds())
+		case "connect":
+			model.ConnectTimeout = ptr.To(timeout.Milliseconds())
+		case "http-keep-alive":
+			model.HTTPKeepAliveTimeout = ptr.To(timeout.Milliseconds())
+		case "http-request":
+			model.HTTPRequestTimeout = ptr.To(timeout.Milliseconds())
+		case "queue":
+			model.QueueTimeout = ptr.To(timeout.Milliseconds())
+		case "server":
+			model.ServerTimeout = ptr.To(timeout.Milliseconds())
+		case "server-fin":
+			model.ServerFinTimeout = ptr.To(timeout.Milliseconds())
+		case "tunnel":
+			model.TunnelTimeout = ptr.To(timeout.Milliseconds())
+		default:
+			return model, fmt.Errorf("timeout %s unknown", name)
+		}
+	}
+
+	for _, ef := range d.Spec.ErrorFiles {
+		m, err := ef.Model()
+		if err != nil {
+			return model, err
+		}
+
+		model.ErrorFiles = append(model.ErrorFiles, &m)
+	}
+
+	if d.Spec.Logging != nil {
+		model.Httplog = ptr.Deref(d.Spec.Logging.HTTPLog, false)
+		model.Tcplog = ptr.Deref(d.Spec.Logging.TCPLog, false)
+	}
+
+	return model, model.Validate(strfmt.Default)
+}
+
+func (d *Defaults) AddToParser(p parser.Parser) error {
+	err := p.SectionsCreate(parser.Defaults, d.Name)
+	if err != nil {
+		return err
+	}
+
+	var defaults models.Defaults
+	defaults, err = d.Model()
+	if err != nil {
+		return err
+	}
+
+	configOpts := &options.ConfigurationOptions{}
+	if err := configuration.CreateEditSection(defaults.DefaultsBase, parser.Defaults, d.Name, p, configOpts); err != nil {
+		return err
+	}
+
+	if d.Spec.Logging != nil && d.Spec.Logging.Enabled {
+		logTarget, err := d.Spec.Logging.Model()
+		if err != nil {
+			return err
+		}
+		if err := p.Insert(parser.Defaults, d.Name, "log", configuration.SerializeLogTarget(logTarget)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//+kubebuilder:object:root=true
+
+// DefaultsList contains a list of Defaults
+type DefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Defaults `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Defaults{}, &DefaultsList{})
+}
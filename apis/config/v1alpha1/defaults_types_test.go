@@ -0,0 +1,74 @@
+package v1alpha1_test
+
+import (
+	"time"
+
+	parser "github.com/haproxytech/client-native/v6/config-parser"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Defaults", Label("type"), func() {
+	Context("AddToParser", func() {
+		var p parser.Parser
+		BeforeEach(func() {
+			var err error
+			p, err = parser.New()
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+		It("should create named defaults", func() {
+			defaults := &configv1alpha1.Defaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "tcp"},
+				Spec: configv1alpha1.DefaultsSpec{
+					Mode: "tcp",
+					Timeouts: map[string]metav1.Duration{
+						"client": {Duration: 30 * time.Second},
+						"tunnel": {Duration: time.Hour},
+					},
+					Logging: &configv1alpha1.DefaultsLogging{
+						Enabled: true,
+						TCPLog:  ptr.To(true),
+					},
+				},
+			}
+			Ω(defaults.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("defaults tcp\n"))
+			Ω(p.String()).Should(ContainSubstring("mode tcp\n"))
+			Ω(p.String()).Should(ContainSubstring("log global\n"))
+			Ω(p.String()).Should(ContainSubstring("option tcplog\n"))
+			Ω(p.String()).Should(ContainSubstring("timeout client 30000\n"))
+			Ω(p.String()).Should(ContainSubstring("timeout tunnel 3600000\n"))
+		})
+		It("should not set unknown timeouts", func() {
+			defaults := &configv1alpha1.Defaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "tcp"},
+				Spec: configv1alpha1.DefaultsSpec{
+					Timeouts: map[string]metav1.Duration{
+						"foo": {Duration: 5 * time.Second},
+					},
+				},
+			}
+			Ω(defaults.AddToParser(p)).Should(HaveOccurred())
+		})
+		It("should inherit from referenced defaults", func() {
+			defaults := &configv1alpha1.Defaults{
+				ObjectMeta: metav1.ObjectMeta{Name: "http"},
+			}
+			backend := &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.BackendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{
+						Defaults: &corev1.LocalObjectReference{Name: "http"},
+					},
+				},
+			}
+			Ω(defaults.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(backend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("backend foo from http\n"))
+		})
+	})
+})
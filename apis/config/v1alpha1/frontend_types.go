@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	parser "github.com/haproxytech/client-native/v6/config-parser"
@@ -16,7 +18,8 @@ import (
 
 // FrontendSpec defines the desired state of Frontend
 type FrontendSpec struct {
-	BaseSpec `json:",inline"`
+	BaseSpec     `json:",inline"`
+	ProxyLogging `json:",inline"`
 	// Binds defines the frontend listening addresses, ports and its configuration.
 	// +kubebuilder:validation:MinItems=1
 	Binds []Bind `json:"binds"`
@@ -25,8 +28,70 @@ type FrontendSpec struct {
 	BackendSwitching []BackendSwitchingRule `json:"backendSwitching,omitempty"`
 	// DefaultBackend to use when no 'use_backend' rule has been matched.
 	DefaultBackend corev1.LocalObjectReference `json:"defaultBackend"`
+	// TLS configures presets for HTTPS, which require mode http.
+	// +optional
+	TLS *FrontendTLS `json:"tls,omitempty"`
+}
+
+type FrontendTLS struct {
+	// Redirect redirects requests received on binds without SSL to HTTPS. Requests for ACME HTTP-01 challenges
+	// below /.well-known/acme-challenge/ are not redirected.
+	// +optional
+	Redirect *HTTPSRedirect `json:"redirect,omitempty"`
+	// HSTS adds the Strict-Transport-Security header to responses on binds with SSL.
+	// +optional
+	HSTS *HSTS `json:"hsts,omitempty"`
+}
+
+type HTTPSRedirect struct {
+	// Code is the HTTP status code of the redirect.
+	// +kubebuilder:validation:Enum=301;302;303;307;308
+	// +kubebuilder:default=301
+	// +optional
+	Code *int64 `json:"code,omitempty"`
+}
+
+type HSTS struct {
+	// MaxAge is the time during which browsers only connect using HTTPS.
+	// +kubebuilder:default="8760h"
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+	// IncludeSubDomains applies the policy to all subdomains.
+	// +optional
+	IncludeSubDomains bool `json:"includeSubDomains,omitempty"`
+	// Preload allows browsers to include the domain in their HSTS preload list. It requires includeSubDomains and
+	// a maxAge of at least one year.
+	// +optional
+	Preload bool `json:"preload,omitempty"`
+}
+
+// Value returns the value of the Strict-Transport-Security header.
+func (h *HSTS) Value() (string, error) {
+	maxAge := hstsMinPreloadMaxAge
+	if h.MaxAge != nil {
+		maxAge = h.MaxAge.Duration
+	}
+
+	value := fmt.Sprintf("max-age=%d", int64(maxAge.Seconds()))
+	if h.IncludeSubDomains {
+		value += "; includeSubDomains"
+	}
+	if h.Preload {
+		if !h.IncludeSubDomains || maxAge < hstsMinPreloadMaxAge {
+			return "", fmt.Errorf("hsts preload requires includeSubDomains and a maxAge of at least one year")
+		}
+		value += "; preload"
+	}
+
+	return value, nil
 }
 
+// hstsMinPreloadMaxAge is the minimal max-age accepted by the HSTS preload lists, which is the default max-age.
+const hstsMinPreloadMaxAge = 365 * 24 * time.Hour
+
+// acmeChallengePathPrefix is the path prefix of ACME HTTP-01 challenges.
+const acmeChallengePathPrefix = "/.well-known/acme-challenge/"
+
 type BackendSwitchingRule struct {
 	Rule `json:",inline"`
 	// Backend reference used to resolve the backend name.
@@ -117,6 +182,10 @@ func (f *Frontend) Model() (models.Frontend, error) {
 		model.Tcplog = *f.Spec.TCPLog
 	}
 
+	if err := f.Spec.ProxyLogging.addToModel(&model.FrontendBase, model.Httplog, model.Tcplog); err != nil {
+		return model, err
+	}
+
 	if f.Spec.Forwardfor != nil {
 		var enabled *string
 		if f.Spec.Forwardfor.Enabled {
@@ -175,7 +244,15 @@ func (f *Frontend) AddToParser(p parser.Parser) error {
 		return err
 	}
 
+	if err := f.Spec.ProxyLogging.addToParser(p, parser.Frontends, f.Name, f.Spec.Mode); err != nil {
+		return err
+	}
+
 	for idx, bind := range f.Spec.Binds {
+		if err := checkProtocolMode(bind.Protocol, f.Spec.Mode); err != nil {
+			return err
+		}
+
 		model, err := bind.Model()
 		if err != nil {
 			return err
@@ -187,6 +264,18 @@ func (f *Frontend) AddToParser(p parser.Parser) error {
 		}
 	}
 
+	if err := f.addAltSvcHeaders(p); err != nil {
+		return err
+	}
+
+	if err := f.addClientCertificateRules(p); err != nil {
+		return err
+	}
+
+	if err := f.addTLSRules(p); err != nil {
+		return err
+	}
+
 	for idx, rule := range f.Spec.BackendSwitching {
 		model, err := rule.Model()
 		if err != nil {
@@ -202,6 +291,153 @@ func (f *Frontend) AddToParser(p parser.Parser) error {
 	return nil
 }
 
+// addAltSvcHeaders announces HTTP/3 on TCP binds which share the port with a QUIC bind.
+func (f *Frontend) addAltSvcHeaders(p parser.Parser) error {
+	if f.Spec.Mode != "http" {
+		return nil
+	}
+
+	for _, quic := range f.Spec.Binds {
+		if !quic.QUIC || !slices.ContainsFunc(f.Spec.Binds, func(bind Bind) bool {
+			return !bind.QUIC && bind.Port == quic.Port
+		}) {
+			continue
+		}
+
+		rule := models.HTTPResponseRule{
+			Type:      "set-header",
+			HdrName:   "alt-svc",
+			HdrFormat: fmt.Sprintf(`"h3=\":%d\"; ma=3600"`, quic.Port),
+			Cond:      "if",
+			CondTest:  fmt.Sprintf("{ dst_port %d } !{ ssl_fc_is_quic }", quic.Port),
+		}
+		data, err := configuration.SerializeHTTPResponseRule(rule, &options.ConfigurationOptions{})
+		if err != nil {
+			return err
+		}
+		if err := p.Insert(parser.Frontends, f.Name, "http-response", data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addTLSRules redirects requests on binds without SSL to HTTPS and adds the HSTS header on binds with SSL.
+func (f *Frontend) addTLSRules(p parser.Parser) error {
+	if f.Spec.TLS == nil {
+		return nil
+	}
+	if f.Spec.Mode != "http" {
+		return fmt.Errorf("tls presets of frontend %s require mode http", f.Name)
+	}
+
+	configOpts := &options.ConfigurationOptions{}
+
+	plain := slices.ContainsFunc(f.Spec.Binds, func(bind Bind) bool {
+		return bind.SSL == nil || !bind.SSL.Enabled
+	})
+	if redirect := f.Spec.TLS.Redirect; redirect != nil && plain {
+		rule := models.HTTPRequestRule{
+			Type:       "redirect",
+			RedirType:  models.HTTPRequestRuleRedirTypeScheme,
+			RedirValue: "https",
+			RedirCode:  ptr.To(ptr.Deref(redirect.Code, 301)),
+			Cond:       "if",
+			CondTest:   fmt.Sprintf("!{ ssl_fc } !{ path_beg %s }", acmeChallengePathPrefix),
+		}
+		data, err := configuration.SerializeHTTPRequestRule(rule, configOpts)
+		if err != nil {
+			return err
+		}
+		if err := p.Insert(parser.Frontends, f.Name, "http-request", data); err != nil {
+			return err
+		}
+	}
+
+	if hsts := f.Spec.TLS.HSTS; hsts != nil {
+		value, err := hsts.Value()
+		if err != nil {
+			return err
+		}
+
+		rule := models.HTTPResponseRule{
+			Type:      "set-header",
+			HdrName:   "Strict-Transport-Security",
+			HdrFormat: fmt.Sprintf(`"%s"`, value),
+			Cond:      "if",
+			CondTest:  "{ ssl_fc }",
+		}
+		data, err := configuration.SerializeHTTPResponseRule(rule, configOpts)
+		if err != nil {
+			return err
+		}
+		if err := p.Insert(parser.Frontends, f.Name, "http-response", data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addClientCertificateRules rejects client certificates not matching the verify host of their bind and
+// forwards client certificate details in request headers.
+func (f *Frontend) addClientCertificateRules(p parser.Parser) error {
+	configOpts := &options.ConfigurationOptions{}
+	removed := map[string]bool{}
+
+	for _, bind := range f.Spec.Binds {
+		verifiesClient := bind.SSL != nil && bind.SSL.Enabled && (bind.SSL.Verify == "optional" || bind.SSL.Verify == "required")
+
+		if bind.SSL != nil && bind.SSL.VerifyHost != "" {
+			if !verifiesClient {
+				return fmt.Errorf("verify host of bind %s requires ssl verify optional or required", bind.Name)
+			}
+
+			rule := models.TCPRequestRule{
+				Type:     "session",
+				Action:   "reject",
+				Cond:     "if",
+				CondTest: fmt.Sprintf("{ so_name %s } !{ ssl_c_s_dn(CN) -m str %s }", bind.Name, bind.SSL.VerifyHost),
+			}
+			data, err := configuration.SerializeTCPRequestRule(rule, configOpts)
+			if err != nil {
+				return err
+			}
+			if err := p.Insert(parser.Frontends, f.Name, "tcp-request", data); err != nil {
+				return err
+			}
+		}
+
+		if bind.ClientCertificateHeaders == nil {
+			continue
+		}
+		if f.Spec.Mode != "http" || !verifiesClient {
+			return fmt.Errorf("client certificate headers of bind %s require mode http and ssl verify optional or required", bind.Name)
+		}
+
+		for _, rule := range bind.ClientCertificateHeaders.Model(bind.Name) {
+			rules := []models.HTTPRequestRule{rule}
+			if !removed[rule.HdrName] {
+				removed[rule.HdrName] = true
+				rules = []models.HTTPRequestRule{{Type: "del-header", HdrName: rule.HdrName}, rule}
+			}
+
+			for _, r := range rules {
+				data, err := configuration.SerializeHTTPRequestRule(r, configOpts)
+				if err != nil {
+					return err
+				}
+				if err := p.Insert(parser.Frontends, f.Name, "http-request", data); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 //+kubebuilder:object:root=true
 
 // FrontendList contains a list of Fronted
@@ -1,7 +1,10 @@
 package v1alpha1_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	parser "github.com/haproxytech/client-native/v6/config-parser"
@@ -120,5 +123,323 @@ var _ = Describe("Frontend", Label("type"), func() {
 			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
 			Ω(p.String()).Should(ContainSubstring("http-response set-header Strict-Transport-Security max-age=16000000; includeSubDomains; preload;"))
 		})
+		It("should set bind protocol", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					Binds: []configv1alpha1.Bind{
+						{Name: "grpc", Address: "*", Port: 9000, Protocol: "h2"},
+					},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(ContainSubstring("bind *:9000 name grpc proto h2\n"))
+		})
+		It("should validate bind protocol against mode and SSL", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "tcp"},
+					Binds: []configv1alpha1.Bind{
+						{Name: "grpc", Address: "*", Port: 9000, Protocol: "h2"},
+					},
+				},
+			}
+			Ω(frontend.AddToParser(p)).Should(HaveOccurred())
+
+			bind := configv1alpha1.Bind{Name: "quic", Address: "quic4@*", Port: 443, Protocol: "quic"}
+			Ω(bind.Model()).Error().Should(HaveOccurred())
+		})
+		It("should add QUIC binds with alt-svc header", func() {
+			ssl := &configv1alpha1.SSL{Enabled: true, Certificate: &configv1alpha1.SSLCertificate{Name: "cert"}}
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					Binds: []configv1alpha1.Bind{
+						{Name: "https", Address: "*", Port: 443, SSL: ssl},
+						{Name: "h3", Address: "*", Port: 443, SSL: ssl, QUIC: true},
+					},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			Ω(p.String()).Should(MatchRegexp(`bind quic4@:443 name h3 .*alpn h3`))
+			Ω(p.String()).Should(ContainSubstring(`http-response set-header alt-svc "h3=\":443\"; ma=3600" if { dst_port 443 } !{ ssl_fc_is_quic }`))
+
+			bind := configv1alpha1.Bind{Name: "h3", Address: "::", Port: 443, QUIC: true}
+			Ω(bind.Model()).Error().Should(HaveOccurred())
+			bind.SSL = ssl
+			model, err := bind.Model()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(model.Address).Should(Equal("quic6@::"))
+		})
+		It("should configure mutual TLS on binds", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					Binds: []configv1alpha1.Bind{
+						{
+							Name: "partner",
+							Port: 8443,
+							SSL: &configv1alpha1.SSL{
+								Enabled:       true,
+								Verify:        "required",
+								Certificate:   &configv1alpha1.SSLCertificate{Name: "cert"},
+								CACertificate: &configv1alpha1.SSLCertificate{Name: "ca"},
+								CRLFile:       &configv1alpha1.SSLCertificate{Name: "crl"},
+								CAVerifyFile:  &configv1alpha1.SSLCertificate{Name: "ca-verify"},
+								VerifyHost:    "partner.example.com",
+								Ciphersuites:  "TLS_AES_256_GCM_SHA384",
+								Curves:        "X25519:P-256",
+							},
+							ClientCertificateHeaders: &configv1alpha1.ClientCertificateHeaders{DN: true, SHA1: true},
+						},
+					},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			config := p.String()
+			Ω(config).Should(ContainSubstring("crl-file /usr/local/etc/haproxy/crl.crt"))
+			Ω(config).Should(ContainSubstring("ca-verify-file /usr/local/etc/haproxy/ca-verify.crt"))
+			Ω(config).Should(ContainSubstring("ciphersuites TLS_AES_256_GCM_SHA384"))
+			Ω(config).Should(ContainSubstring("curves X25519:P-256"))
+			Ω(config).Should(ContainSubstring("tcp-request session reject if { so_name partner } !{ ssl_c_s_dn(CN) -m str partner.example.com }\n"))
+			Ω(config).Should(ContainSubstring("http-request del-header X-SSL-Client-DN\n"))
+			Ω(config).Should(ContainSubstring("http-request set-header X-SSL-Client-DN %{+Q}[ssl_c_s_dn] if { so_name partner }\n"))
+			Ω(config).Should(ContainSubstring("http-request set-header X-SSL-Client-SHA1 %[ssl_c_sha1,hex] if { so_name partner }\n"))
+			Ω(config).ShouldNot(ContainSubstring("X-SSL-Client-Serial"))
+		})
+		It("should require client verification for client certificate headers", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					Binds: []configv1alpha1.Bind{
+						{
+							Name:                     "https",
+							Port:                     443,
+							SSL:                      &configv1alpha1.SSL{Enabled: true},
+							ClientCertificateHeaders: &configv1alpha1.ClientCertificateHeaders{Verify: true},
+						},
+					},
+				},
+			}
+			Ω(frontend.AddToParser(p)).Should(HaveOccurred())
+		})
+		It("should redirect to HTTPS and add HSTS headers", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					Binds: []configv1alpha1.Bind{
+						{Name: "http", Port: 80},
+						{Name: "https", Port: 443, SSL: &configv1alpha1.SSL{Enabled: true, Certificate: &configv1alpha1.SSLCertificate{Name: "cert"}}},
+					},
+					TLS: &configv1alpha1.FrontendTLS{
+						Redirect: &configv1alpha1.HTTPSRedirect{Code: ptr.To(int64(308))},
+						HSTS:     &configv1alpha1.HSTS{IncludeSubDomains: true, Preload: true},
+					},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			config := p.String()
+			Ω(config).Should(ContainSubstring("http-request redirect scheme https code 308 if !{ ssl_fc } !{ path_beg /.well-known/acme-challenge/ }\n"))
+			Ω(config).Should(ContainSubstring(`http-response set-header Strict-Transport-Security "max-age=31536000; includeSubDomains; preload" if { ssl_fc }`))
+
+			hsts := configv1alpha1.HSTS{MaxAge: &metav1.Duration{Duration: time.Hour}, IncludeSubDomains: true, Preload: true}
+			Ω(hsts.Value()).Error().Should(HaveOccurred())
+			hsts.Preload = false
+			Ω(hsts.Value()).Should(Equal("max-age=3600; includeSubDomains"))
+		})
+		It("should require mode http for TLS presets", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "tcp"},
+					Binds:    []configv1alpha1.Bind{{Name: "http", Port: 80}},
+					TLS:      &configv1alpha1.FrontendTLS{Redirect: &configv1alpha1.HTTPSRedirect{}},
+				},
+			}
+			Ω(frontend.AddToParser(p)).Should(HaveOccurred())
+		})
+		It("should add log formats and log targets", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					ProxyLogging: configv1alpha1.ProxyLogging{
+						LogFormat: &configv1alpha1.LogFormat{
+							Fields: []configv1alpha1.LogField{
+								{Name: "client_ip", Value: "client-ip"},
+								{Name: "status", Value: "status"},
+								{Name: "host", Expression: "%[capture.req.hdr(0)]"},
+							},
+						},
+						ErrorLogFormat: `"%ci:%cp %ft %tsc"`,
+						LogTargets:     []configv1alpha1.LogTarget{{Address: "10.0.0.1:514", Facility: "local1", Format: "rfc5424"}},
+					},
+					Binds: []configv1alpha1.Bind{{Name: "http", Port: 80}},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			config := p.String()
+			Ω(config).Should(ContainSubstring(`log-format '{"client_ip":"%ci","status":"%ST","host":"%[capture.req.hdr(0)]"}'`))
+			Ω(config).Should(ContainSubstring(`error-log-format "%ci:%cp %ft %tsc"`))
+			Ω(config).Should(ContainSubstring("log 10.0.0.1:514 format rfc5424 local1\n"))
+		})
+		It("should build log formats from presets", func() {
+			format := configv1alpha1.LogFormat{Preset: "json", Fields: []configv1alpha1.LogField{{Name: "id", Value: "unique-id"}}}
+			value, err := format.String()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(value).Should(HavePrefix(`'{"client_ip":"%ci","client_port":"%cp",`))
+			Ω(value).Should(HaveSuffix(`"retries":"%rc","id":"%[unique-id,json(utf8s)]"}'`))
+
+			format = configv1alpha1.LogFormat{Preset: "clf"}
+			Ω(format.String()).Should(HavePrefix(`'%{+Q}o %{-Q}ci - - [%trg] %r %ST %B "" ""`))
+
+			format = configv1alpha1.LogFormat{Fields: []configv1alpha1.LogField{{Name: "quote", Expression: "%[req.hdr(x-quote)]'"}}}
+			Ω(format.String()).Should(Equal(`'{"quote":"%[req.hdr(x-quote)]'\''"}'`))
+
+			format = configv1alpha1.LogFormat{Preset: "clf", Raw: "%ci"}
+			Ω(format.String()).Error().Should(HaveOccurred())
+			format = configv1alpha1.LogFormat{Fields: []configv1alpha1.LogField{{Name: "empty"}}}
+			Ω(format.String()).Error().Should(HaveOccurred())
+		})
+		It("should escape values controlled by the client in JSON log formats", func() {
+			format := configv1alpha1.LogFormat{
+				Preset: "json",
+				Fields: []configv1alpha1.LogField{
+					{Name: "path", Value: "path"},
+					{Name: "query", Value: "query"},
+					{Name: "request_line", Value: "request-line"},
+					{Name: "unique_id", Value: "unique-id"},
+				},
+			}
+			value, err := format.String()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(value).Should(ContainSubstring(`"uri":"%[capture.req.uri,json(utf8s)]"`))
+			Ω(value).Should(ContainSubstring(`"request_line":"%[capture.req.method,json(utf8s)] %[capture.req.uri,json(utf8s)] %[capture.req.ver,json(utf8s)]"`))
+
+			// HAProxy replaces the escaped samples with the JSON encoded value and all other variables as they are
+			quote, err := json.Marshal(`/a"b`)
+			Ω(err).ShouldNot(HaveOccurred())
+			line := regexp.MustCompile(`%\[[^\]]*,json\(utf8s\)\]`).ReplaceAllString(strings.Trim(value, "'"), strings.Trim(string(quote), `"`))
+			line = regexp.MustCompile(`%[A-Za-z]+`).ReplaceAllString(line, "0")
+
+			fields := map[string]string{}
+			Ω(json.Unmarshal([]byte(line), &fields)).ShouldNot(HaveOccurred())
+			Ω(fields).Should(HaveKeyWithValue("uri", `/a"b`))
+			Ω(fields).Should(HaveKeyWithValue("path", `/a"b`))
+			Ω(fields).Should(HaveKeyWithValue("query", `/a"b`))
+			Ω(fields).Should(HaveKeyWithValue("unique_id", `/a"b`))
+		})
+		It("should capture headers and log them", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{
+						Mode: "http",
+						HTTPRequest: &configv1alpha1.HTTPRequestRules{
+							Deny: []configv1alpha1.Deny{{Enabled: true}},
+						},
+					},
+					ProxyLogging: configv1alpha1.ProxyLogging{
+						LogFormat: &configv1alpha1.LogFormat{Preset: "json"},
+						Captures: &configv1alpha1.Captures{
+							RequestHeaders:  []configv1alpha1.HeaderCapture{{Name: "Host", Length: 128}, {Name: "User-Agent"}},
+							ResponseHeaders: []configv1alpha1.HeaderCapture{{Name: "X-Request-ID", Length: 36}},
+						},
+					},
+					Binds: []configv1alpha1.Bind{{Name: "http", Port: 80}},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			config := p.String()
+			Ω(config).Should(ContainSubstring("http-request capture req.hdr(Host) len 128\n  http-request capture req.hdr(User-Agent) len 64\n  http-request deny\n"))
+			Ω(config).Should(ContainSubstring("declare capture response len 36\n"))
+			Ω(config).Should(ContainSubstring("http-response capture res.hdr(X-Request-ID) id 0\n"))
+			Ω(config).Should(ContainSubstring(`"retries":"%rc","request_host":"%[capture.req.hdr(0),json(utf8s)]","request_user_agent":"%[capture.req.hdr(1),json(utf8s)]","response_x_request_id":"%[capture.res.hdr(0),json(utf8s)]"}'`))
+		})
+		It("should generate and forward request IDs", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec: configv1alpha1.BaseSpec{Mode: "http"},
+					ProxyLogging: configv1alpha1.ProxyLogging{
+						LogFormat: &configv1alpha1.LogFormat{Fields: []configv1alpha1.LogField{{Name: "status", Value: "status"}}},
+						Captures:  &configv1alpha1.Captures{RequestHeaders: []configv1alpha1.HeaderCapture{{Name: "X-Request-ID"}}},
+						UniqueID:  &configv1alpha1.UniqueID{Preset: "request-id"},
+					},
+					Binds: []configv1alpha1.Bind{{Name: "http", Port: 80}},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			config := p.String()
+			Ω(config).Should(ContainSubstring("unique-id-format %[req.hdr(X-Request-ID)]\n"))
+			Ω(config).ShouldNot(ContainSubstring("unique-id-header"))
+			Ω(config).Should(ContainSubstring("http-request capture req.hdr(X-Request-ID) len 64\n  http-request set-header X-Request-ID %[uuid()] unless { req.fhdr_cnt(X-Request-ID) eq 1 } { req.fhdr(X-Request-ID) -m reg ^[A-Za-z0-9._:-]{1,128}$ }\n"))
+
+			// IDs which would break the logs or are too long are replaced
+			pattern := regexp.MustCompile(regexp.MustCompile(`X-Request-ID\) -m reg (\S+) }`).FindStringSubmatch(config)[1])
+			Ω(pattern.MatchString("0b9e5c1c-4a4f-4c4e-9b7e-3c2f1d0e8a7b")).Should(BeTrue())
+			Ω(pattern.MatchString(`a"b`)).Should(BeFalse())
+			Ω(pattern.MatchString("a b")).Should(BeFalse())
+			Ω(pattern.MatchString(strings.Repeat("a", 129))).Should(BeFalse())
+			Ω(config).Should(ContainSubstring(`log-format '{"unique_id":"%[unique-id,json(utf8s)]","request_x_request_id":"%[capture.req.hdr(0),json(utf8s)]","status":"%ST"}'`))
+		})
+		It("should add unique ID formats and headers", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec:     configv1alpha1.BaseSpec{Mode: "http"},
+					ProxyLogging: configv1alpha1.ProxyLogging{UniqueID: &configv1alpha1.UniqueID{Format: "%{+X}o%ci:%cp_%Ts_%rt:%pid", Header: "X-Unique-ID"}},
+					Binds:        []configv1alpha1.Bind{{Name: "http", Port: 80}},
+				},
+			}
+			Ω(frontend.AddToParser(p)).ShouldNot(HaveOccurred())
+			config := p.String()
+			Ω(config).Should(ContainSubstring("unique-id-format %{+X}o%ci:%cp_%Ts_%rt:%pid\n"))
+			Ω(config).Should(ContainSubstring("unique-id-header X-Unique-ID\n"))
+			Ω(config).ShouldNot(ContainSubstring("uuid()"))
+
+			uniqueID := configv1alpha1.UniqueID{Preset: "request-id", Format: "%ci"}
+			frontend.Spec.UniqueID = &uniqueID
+			_, err := frontend.Model()
+			Ω(err).Should(HaveOccurred())
+		})
+		It("should require mode http for captures", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec:     configv1alpha1.BaseSpec{Mode: "tcp"},
+					ProxyLogging: configv1alpha1.ProxyLogging{Captures: &configv1alpha1.Captures{RequestHeaders: []configv1alpha1.HeaderCapture{{Name: "Host"}}}},
+					Binds:        []configv1alpha1.Bind{{Name: "tcp", Port: 5432}},
+				},
+			}
+			Ω(frontend.AddToParser(p)).Should(HaveOccurred())
+		})
+		It("should not combine log formats with httplog", func() {
+			frontend := &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: configv1alpha1.FrontendSpec{
+					BaseSpec:     configv1alpha1.BaseSpec{Mode: "http", HTTPLog: ptr.To(true)},
+					ProxyLogging: configv1alpha1.ProxyLogging{LogFormat: &configv1alpha1.LogFormat{Preset: "json"}},
+					Binds:        []configv1alpha1.Bind{{Name: "http", Port: 80}},
+				},
+			}
+			Ω(frontend.AddToParser(p)).Should(HaveOccurred())
+		})
+		It("should use the TLS ticket keys of the instance", func() {
+			bind := configv1alpha1.Bind{
+				Name: "https",
+				Port: 443,
+				SSL:  &configv1alpha1.SSL{Enabled: true, TLSTicketKeys: true},
+			}
+			model, err := bind.Model()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(model.TLSTicketKeys).Should(Equal("/usr/local/etc/haproxy/tls-ticket.keys"))
+		})
 	})
 })
@@ -9,7 +9,8 @@ import (
 
 // ListenSpec defines the desired state of Listen
 type ListenSpec struct {
-	BaseSpec `json:",inline"`
+	BaseSpec     `json:",inline"`
+	ProxyLogging `json:",inline"`
 	// Binds defines the frontend listening addresses, ports and its configuration.
 	// +kubebuilder:validation:MinItems=1
 	Binds []Bind `json:"binds"`
@@ -29,6 +30,25 @@ type ListenSpec struct {
 	// Redispatch enable or disable session redistribution in case of connection failure
 	// +optional
 	Redispatch *bool `json:"redispatch,omitempty"`
+	// RedispatchInterval is the number of retries after which a connection is redispatched to another server.
+	// A negative value redispatches on the last retry. Defaults to 3.
+	// +optional
+	RedispatchInterval *int64 `json:"redispatchInterval,omitempty"`
+	// Retries is the number of retries to perform on a server after a failure.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries *int64 `json:"retries,omitempty"`
+	// RetryOn specifies the conditions which trigger a retry.
+	// +kubebuilder:validation:items:Enum=none;conn-failure;empty-response;junk-response;response-timeout;"0rtt-rejected";all-retryable-errors;"401";"403";"404";"408";"425";"500";"501";"502";"503";"504"
+	// +optional
+	RetryOn []string `json:"retryOn,omitempty"`
+	// HTTPReuse declares how idle HTTP connections to servers may be shared between requests.
+	// +kubebuilder:validation:Enum=never;safe;aggressive;always
+	// +optional
+	HTTPReuse string `json:"httpReuse,omitempty"`
+	// AbortOnClose enables early dropping of aborted requests pending in queues.
+	// +optional
+	AbortOnClose *bool `json:"abortOnClose,omitempty"`
 	// HashType Specify a method to use for mapping hashes to servers
 	// +optional
 	HashType *HashType `json:"hashType,omitempty"`
@@ -44,6 +64,23 @@ type ListenSpec struct {
 	// TCPCheck Perform health checks using tcp-check send/expect sequences
 	// +optional
 	TCPCheck *bool `json:"tcpCheck,omitempty"`
+	// HTTPCheckRules defines a sequence of http-check rules. It enables HTTP health checks and cannot be combined
+	// with httpCheck or TCP health checks.
+	// +optional
+	HTTPCheckRules []HTTPCheckRule `json:"httpCheckRules,omitempty"`
+	// TCPCheckRules defines a sequence of tcp-check rules. It enables TCP health checks and cannot be combined with
+	// HTTP health checks.
+	// +optional
+	TCPCheckRules []TCPCheckRule `json:"tcpCheckRules,omitempty"`
+	// FullConn is the number of connections at which the backend is considered at full load. It is used to compute
+	// the dynamic maxconn of servers with MinConn.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	FullConn *int64 `json:"fullconn,omitempty"`
+	// AllBackups uses all backup servers at once when all non-backup servers are unavailable, instead of only the
+	// first one.
+	// +optional
+	AllBackups *bool `json:"allBackups,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -75,8 +112,9 @@ func (l *Listen) ToFrontend() *Frontend {
 		TypeMeta:   l.TypeMeta,
 		ObjectMeta: l.ObjectMeta,
 		Spec: FrontendSpec{
-			BaseSpec: l.Spec.BaseSpec,
-			Binds:    l.Spec.Binds,
+			BaseSpec:     l.Spec.BaseSpec,
+			ProxyLogging: l.Spec.ProxyLogging,
+			Binds:        l.Spec.Binds,
 			DefaultBackend: corev1.LocalObjectReference{
 				Name: "be-" + l.Name,
 			},
@@ -101,17 +139,26 @@ func (l *Listen) ToBackend() *Backend {
 		TypeMeta:   l.TypeMeta,
 		ObjectMeta: l.ObjectMeta,
 		Spec: BackendSpec{
-			BaseSpec:        l.Spec.BaseSpec,
-			CheckTimeout:    l.Spec.CheckTimeout,
-			Servers:         l.Spec.Servers,
-			ServerTemplates: l.Spec.ServerTemplates,
-			Balance:         l.Spec.Balance,
-			Redispatch:      l.Spec.Redispatch,
-			HashType:        l.Spec.HashType,
-			Cookie:          l.Spec.Cookie,
-			HostCertificate: l.Spec.HostCertificate,
-			HTTPChk:         l.Spec.HTTPCheck,
-			TCPCheck:        l.Spec.TCPCheck,
+			BaseSpec:           l.Spec.BaseSpec,
+			CheckTimeout:       l.Spec.CheckTimeout,
+			Servers:            l.Spec.Servers,
+			ServerTemplates:    l.Spec.ServerTemplates,
+			Balance:            l.Spec.Balance,
+			Redispatch:         l.Spec.Redispatch,
+			RedispatchInterval: l.Spec.RedispatchInterval,
+			Retries:            l.Spec.Retries,
+			RetryOn:            l.Spec.RetryOn,
+			HTTPReuse:          l.Spec.HTTPReuse,
+			AbortOnClose:       l.Spec.AbortOnClose,
+			HashType:           l.Spec.HashType,
+			Cookie:             l.Spec.Cookie,
+			HostCertificate:    l.Spec.HostCertificate,
+			HTTPChk:            l.Spec.HTTPCheck,
+			TCPCheck:           l.Spec.TCPCheck,
+			HTTPCheckRules:     l.Spec.HTTPCheckRules,
+			TCPCheckRules:      l.Spec.TCPCheckRules,
+			FullConn:           l.Spec.FullConn,
+			AllBackups:         l.Spec.AllBackups,
 		},
 	}
 
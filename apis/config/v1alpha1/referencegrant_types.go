@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReferenceGrantSpec defines which namespaces may reference Secrets of the namespace of the grant.
+type ReferenceGrantSpec struct {
+	// From lists the namespaces whose objects may reference the Secrets.
+	// +kubebuilder:validation:MinItems=1
+	From []ReferenceGrantFrom `json:"from"`
+	// To lists the Secrets which may be referenced.
+	// +kubebuilder:validation:MinItems=1
+	To []ReferenceGrantTo `json:"to"`
+}
+
+type ReferenceGrantFrom struct {
+	// Namespace of the referencing objects, which is the namespace of their instance.
+	Namespace string `json:"namespace"`
+}
+
+type ReferenceGrantTo struct {
+	// Name of the Secret. All Secrets of the namespace may be referenced if it is empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReferenceGrant allows objects of other namespaces to reference Secrets in its namespace, e.g. with
+// secretKeyExternalRef or the namespaces of a secretSelector. References to Secrets of other namespaces are denied
+// unless a ReferenceGrant in the namespace of the Secret allows them.
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReferenceGrantSpec `json:"spec,omitempty"`
+}
+
+// Allows returns true if the grant allows objects of the namespace to reference the Secret.
+func (g *ReferenceGrant) Allows(namespace, secret string) bool {
+	from := slices.ContainsFunc(g.Spec.From, func(from ReferenceGrantFrom) bool {
+		return from.Namespace == namespace
+	})
+	to := slices.ContainsFunc(g.Spec.To, func(to ReferenceGrantTo) bool {
+		return to.Name == "" || to.Name == secret
+	})
+
+	return from && to
+}
+
+//+kubebuilder:object:root=true
+
+// ReferenceGrantList contains a list of ReferenceGrant
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReferenceGrant{}, &ReferenceGrantList{})
+}
@@ -46,13 +46,53 @@ func (in *ACL) DeepCopy() *ACL {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMECertificate) DeepCopyInto(out *ACMECertificate) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMECertificate.
+func (in *ACMECertificate) DeepCopy() *ACMECertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMECertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentCheck) DeepCopyInto(out *AgentCheck) {
+	*out = *in
+	if in.Inter != nil {
+		in, out := &in.Inter, &out.Inter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentCheck.
+func (in *AgentCheck) DeepCopy() *AgentCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backend) DeepCopyInto(out *Backend) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backend.
@@ -153,10 +193,15 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ServiceRef != nil {
+		in, out := &in.ServiceRef, &out.ServiceRef
+		*out = new(ServiceReference)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Balance != nil {
 		in, out := &in.Balance, &out.Balance
 		*out = new(Balance)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostCertificate != nil {
 		in, out := &in.HostCertificate, &out.HostCertificate
@@ -168,6 +213,26 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RedispatchInterval != nil {
+		in, out := &in.RedispatchInterval, &out.RedispatchInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AbortOnClose != nil {
+		in, out := &in.AbortOnClose, &out.AbortOnClose
+		*out = new(bool)
+		**out = **in
+	}
 	if in.HashType != nil {
 		in, out := &in.HashType, &out.HashType
 		*out = new(HashType)
@@ -188,6 +253,30 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HTTPCheckRules != nil {
+		in, out := &in.HTTPCheckRules, &out.HTTPCheckRules
+		*out = make([]HTTPCheckRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TCPCheckRules != nil {
+		in, out := &in.TCPCheckRules, &out.TCPCheckRules
+		*out = make([]TCPCheckRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FullConn != nil {
+		in, out := &in.FullConn, &out.FullConn
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllBackups != nil {
+		in, out := &in.AllBackups, &out.AllBackups
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
@@ -220,6 +309,41 @@ func (in *BackendSwitchingRule) DeepCopy() *BackendSwitchingRule {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Balance) DeepCopyInto(out *Balance) {
 	*out = *in
+	if in.URI != nil {
+		in, out := &in.URI, &out.URI
+		*out = new(BalanceURI)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.URLParam != nil {
+		in, out := &in.URLParam, &out.URLParam
+		*out = new(BalanceURLParam)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hdr != nil {
+		in, out := &in.Hdr, &out.Hdr
+		*out = new(BalanceHdr)
+		**out = **in
+	}
+	if in.RdpCookie != nil {
+		in, out := &in.RdpCookie, &out.RdpCookie
+		*out = new(BalanceRdpCookie)
+		**out = **in
+	}
+	if in.Random != nil {
+		in, out := &in.Random, &out.Random
+		*out = new(BalanceRandom)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hash != nil {
+		in, out := &in.Hash, &out.Hash
+		*out = new(BalanceHash)
+		**out = **in
+	}
+	if in.HashBalanceFactor != nil {
+		in, out := &in.HashBalanceFactor, &out.HashBalanceFactor
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Balance.
@@ -232,9 +356,129 @@ func (in *Balance) DeepCopy() *Balance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalanceHash) DeepCopyInto(out *BalanceHash) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalanceHash.
+func (in *BalanceHash) DeepCopy() *BalanceHash {
+	if in == nil {
+		return nil
+	}
+	out := new(BalanceHash)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalanceHdr) DeepCopyInto(out *BalanceHdr) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalanceHdr.
+func (in *BalanceHdr) DeepCopy() *BalanceHdr {
+	if in == nil {
+		return nil
+	}
+	out := new(BalanceHdr)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalanceRandom) DeepCopyInto(out *BalanceRandom) {
+	*out = *in
+	if in.Draws != nil {
+		in, out := &in.Draws, &out.Draws
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalanceRandom.
+func (in *BalanceRandom) DeepCopy() *BalanceRandom {
+	if in == nil {
+		return nil
+	}
+	out := new(BalanceRandom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalanceRdpCookie) DeepCopyInto(out *BalanceRdpCookie) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalanceRdpCookie.
+func (in *BalanceRdpCookie) DeepCopy() *BalanceRdpCookie {
+	if in == nil {
+		return nil
+	}
+	out := new(BalanceRdpCookie)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalanceURI) DeepCopyInto(out *BalanceURI) {
+	*out = *in
+	if in.Len != nil {
+		in, out := &in.Len, &out.Len
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Depth != nil {
+		in, out := &in.Depth, &out.Depth
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalanceURI.
+func (in *BalanceURI) DeepCopy() *BalanceURI {
+	if in == nil {
+		return nil
+	}
+	out := new(BalanceURI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalanceURLParam) DeepCopyInto(out *BalanceURLParam) {
+	*out = *in
+	if in.CheckPost != nil {
+		in, out := &in.CheckPost, &out.CheckPost
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxWait != nil {
+		in, out := &in.MaxWait, &out.MaxWait
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalanceURLParam.
+func (in *BalanceURLParam) DeepCopy() *BalanceURLParam {
+	if in == nil {
+		return nil
+	}
+	out := new(BalanceURLParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaseSpec) DeepCopyInto(out *BaseSpec) {
 	*out = *in
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	if in.HTTPResponse != nil {
 		in, out := &in.HTTPResponse, &out.HTTPResponse
 		*out = new(HTTPResponseRules)
@@ -327,183 +571,640 @@ func (in *Bind) DeepCopyInto(out *Bind) {
 		*out = new(CertificateList)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Hidden != nil {
-		in, out := &in.Hidden, &out.Hidden
-		*out = new(bool)
-		**out = **in
-	}
-	if in.AcceptProxy != nil {
-		in, out := &in.AcceptProxy, &out.AcceptProxy
+	if in.Hidden != nil {
+		in, out := &in.Hidden, &out.Hidden
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AcceptProxy != nil {
+		in, out := &in.AcceptProxy, &out.AcceptProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClientCertificateHeaders != nil {
+		in, out := &in.ClientCertificateHeaders, &out.ClientCertificateHeaders
+		*out = new(ClientCertificateHeaders)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind.
+func (in *Bind) DeepCopy() *Bind {
+	if in == nil {
+		return nil
+	}
+	out := new(Bind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Captures) DeepCopyInto(out *Captures) {
+	*out = *in
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make([]HeaderCapture, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]HeaderCapture, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Captures.
+func (in *Captures) DeepCopy() *Captures {
+	if in == nil {
+		return nil
+	}
+	out := new(Captures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerCertificate) DeepCopyInto(out *CertManagerCertificate) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerCertificate.
+func (in *CertManagerCertificate) DeepCopy() *CertManagerCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateList) DeepCopyInto(out *CertificateList) {
+	*out = *in
+	if in.Elements != nil {
+		in, out := &in.Elements, &out.Elements
+		*out = make([]CertificateListElement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretSelector != nil {
+		in, out := &in.SecretSelector, &out.SecretSelector
+		*out = new(CertificateSecretSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateList.
+func (in *CertificateList) DeepCopy() *CertificateList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateListElement) DeepCopyInto(out *CertificateListElement) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.Alpn != nil {
+		in, out := &in.Alpn, &out.Alpn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OcspFile != nil {
+		in, out := &in.OcspFile, &out.OcspFile
+		*out = new(OcspFile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = new(ACMECertificate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateListElement.
+func (in *CertificateListElement) DeepCopy() *CertificateListElement {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateListElement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateSecretSelector) DeepCopyInto(out *CertificateSecretSelector) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Alpn != nil {
+		in, out := &in.Alpn, &out.Alpn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateSecretSelector.
+func (in *CertificateSecretSelector) DeepCopy() *CertificateSecretSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateSecretSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Check) DeepCopyInto(out *Check) {
+	*out = *in
+	if in.Inter != nil {
+		in, out := &in.Inter, &out.Inter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Rise != nil {
+		in, out := &in.Rise, &out.Rise
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Fall != nil {
+		in, out := &in.Fall, &out.Fall
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SSL != nil {
+		in, out := &in.SSL, &out.SSL
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Check.
+func (in *Check) DeepCopy() *Check {
+	if in == nil {
+		return nil
+	}
+	out := new(Check)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckConnect) DeepCopyInto(out *CheckConnect) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Alpn != nil {
+		in, out := &in.Alpn, &out.Alpn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckConnect.
+func (in *CheckConnect) DeepCopy() *CheckConnect {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckConnect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckExpect) DeepCopyInto(out *CheckExpect) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckExpect.
+func (in *CheckExpect) DeepCopy() *CheckExpect {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckExpect)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheckHeader) DeepCopyInto(out *CheckHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckHeader.
+func (in *CheckHeader) DeepCopy() *CheckHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(CheckHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateHeaders) DeepCopyInto(out *ClientCertificateHeaders) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateHeaders.
+func (in *ClientCertificateHeaders) DeepCopy() *ClientCertificateHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cookie) DeepCopyInto(out *Cookie) {
+	*out = *in
+	out.Mode = in.Mode
+	if in.Indirect != nil {
+		in, out := &in.Indirect, &out.Indirect
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NoCache != nil {
+		in, out := &in.NoCache, &out.NoCache
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PostOnly != nil {
+		in, out := &in.PostOnly, &out.PostOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Preserve != nil {
+		in, out := &in.Preserve, &out.Preserve
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HTTPOnly != nil {
+		in, out := &in.HTTPOnly, &out.HTTPOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Secure != nil {
+		in, out := &in.Secure, &out.Secure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Dynamic != nil {
+		in, out := &in.Dynamic, &out.Dynamic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attribute != nil {
+		in, out := &in.Attribute, &out.Attribute
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cookie.
+func (in *Cookie) DeepCopy() *Cookie {
+	if in == nil {
+		return nil
+	}
+	out := new(Cookie)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CookieMode) DeepCopyInto(out *CookieMode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieMode.
+func (in *CookieMode) DeepCopy() *CookieMode {
+	if in == nil {
+		return nil
+	}
+	out := new(CookieMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrtStore) DeepCopyInto(out *CrtStore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrtStore.
+func (in *CrtStore) DeepCopy() *CrtStore {
+	if in == nil {
+		return nil
+	}
+	out := new(CrtStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CrtStore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrtStoreCertificate) DeepCopyInto(out *CrtStoreCertificate) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(SSLCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OcspFile != nil {
+		in, out := &in.OcspFile, &out.OcspFile
+		*out = new(OcspFile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OcspUpdate != nil {
+		in, out := &in.OcspUpdate, &out.OcspUpdate
 		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bind.
-func (in *Bind) DeepCopy() *Bind {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrtStoreCertificate.
+func (in *CrtStoreCertificate) DeepCopy() *CrtStoreCertificate {
 	if in == nil {
 		return nil
 	}
-	out := new(Bind)
+	out := new(CrtStoreCertificate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateList) DeepCopyInto(out *CertificateList) {
+func (in *CrtStoreList) DeepCopyInto(out *CrtStoreList) {
 	*out = *in
-	if in.Elements != nil {
-		in, out := &in.Elements, &out.Elements
-		*out = make([]CertificateListElement, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CrtStore, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.LabelSelector != nil {
-		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateList.
-func (in *CertificateList) DeepCopy() *CertificateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrtStoreList.
+func (in *CrtStoreList) DeepCopy() *CrtStoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateList)
+	out := new(CrtStoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CrtStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateListElement) DeepCopyInto(out *CertificateListElement) {
+func (in *CrtStoreReference) DeepCopyInto(out *CrtStoreReference) {
 	*out = *in
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	if in.Alpn != nil {
-		in, out := &in.Alpn, &out.Alpn
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.OcspFile != nil {
-		in, out := &in.OcspFile, &out.OcspFile
-		*out = new(OcspFile)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateListElement.
-func (in *CertificateListElement) DeepCopy() *CertificateListElement {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrtStoreReference.
+func (in *CrtStoreReference) DeepCopy() *CrtStoreReference {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateListElement)
+	out := new(CrtStoreReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Check) DeepCopyInto(out *Check) {
+func (in *CrtStoreSpec) DeepCopyInto(out *CrtStoreSpec) {
 	*out = *in
-	if in.Inter != nil {
-		in, out := &in.Inter, &out.Inter
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.Rise != nil {
-		in, out := &in.Rise, &out.Rise
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Fall != nil {
-		in, out := &in.Fall, &out.Fall
-		*out = new(int64)
-		**out = **in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CrtStoreCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Check.
-func (in *Check) DeepCopy() *Check {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrtStoreSpec.
+func (in *CrtStoreSpec) DeepCopy() *CrtStoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Check)
+	out := new(CrtStoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Cookie) DeepCopyInto(out *Cookie) {
+func (in *Defaults) DeepCopyInto(out *Defaults) {
 	*out = *in
-	out.Mode = in.Mode
-	if in.Indirect != nil {
-		in, out := &in.Indirect, &out.Indirect
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Defaults.
+func (in *Defaults) DeepCopy() *Defaults {
+	if in == nil {
+		return nil
 	}
-	if in.NoCache != nil {
-		in, out := &in.NoCache, &out.NoCache
-		*out = new(bool)
-		**out = **in
+	out := new(Defaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Defaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.PostOnly != nil {
-		in, out := &in.PostOnly, &out.PostOnly
-		*out = new(bool)
-		**out = **in
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultsList) DeepCopyInto(out *DefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Defaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Preserve != nil {
-		in, out := &in.Preserve, &out.Preserve
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultsList.
+func (in *DefaultsList) DeepCopy() *DefaultsList {
+	if in == nil {
+		return nil
 	}
-	if in.HTTPOnly != nil {
-		in, out := &in.HTTPOnly, &out.HTTPOnly
-		*out = new(bool)
-		**out = **in
+	out := new(DefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.Secure != nil {
-		in, out := &in.Secure, &out.Secure
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultsLogging) DeepCopyInto(out *DefaultsLogging) {
+	*out = *in
+	if in.HTTPLog != nil {
+		in, out := &in.HTTPLog, &out.HTTPLog
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Dynamic != nil {
-		in, out := &in.Dynamic, &out.Dynamic
+	if in.TCPLog != nil {
+		in, out := &in.TCPLog, &out.TCPLog
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Domain != nil {
-		in, out := &in.Domain, &out.Domain
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Attribute != nil {
-		in, out := &in.Attribute, &out.Attribute
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cookie.
-func (in *Cookie) DeepCopy() *Cookie {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultsLogging.
+func (in *DefaultsLogging) DeepCopy() *DefaultsLogging {
 	if in == nil {
 		return nil
 	}
-	out := new(Cookie)
+	out := new(DefaultsLogging)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CookieMode) DeepCopyInto(out *CookieMode) {
+func (in *DefaultsSpec) DeepCopyInto(out *DefaultsSpec) {
 	*out = *in
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = make(map[string]v1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ErrorFiles != nil {
+		in, out := &in.ErrorFiles, &out.ErrorFiles
+		*out = make([]*ErrorFile, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ErrorFile)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(DefaultsLogging)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieMode.
-func (in *CookieMode) DeepCopy() *CookieMode {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultsSpec.
+func (in *DefaultsSpec) DeepCopy() *DefaultsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CookieMode)
+	out := new(DefaultsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -586,7 +1287,7 @@ func (in *Frontend) DeepCopyInto(out *Frontend) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Frontend.
@@ -643,6 +1344,7 @@ func (in *FrontendList) DeepCopyObject() runtime.Object {
 func (in *FrontendSpec) DeepCopyInto(out *FrontendSpec) {
 	*out = *in
 	in.BaseSpec.DeepCopyInto(&out.BaseSpec)
+	in.ProxyLogging.DeepCopyInto(&out.ProxyLogging)
 	if in.Binds != nil {
 		in, out := &in.Binds, &out.Binds
 		*out = make([]Bind, len(*in))
@@ -658,6 +1360,11 @@ func (in *FrontendSpec) DeepCopyInto(out *FrontendSpec) {
 		}
 	}
 	out.DefaultBackend = in.DefaultBackend
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(FrontendTLS)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendSpec.
@@ -670,6 +1377,81 @@ func (in *FrontendSpec) DeepCopy() *FrontendSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontendTLS) DeepCopyInto(out *FrontendTLS) {
+	*out = *in
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(HTTPSRedirect)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HSTS != nil {
+		in, out := &in.HSTS, &out.HSTS
+		*out = new(HSTS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontendTLS.
+func (in *FrontendTLS) DeepCopy() *FrontendTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontendTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HSTS) DeepCopyInto(out *HSTS) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HSTS.
+func (in *HSTS) DeepCopy() *HSTS {
+	if in == nil {
+		return nil
+	}
+	out := new(HSTS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPCheckRule) DeepCopyInto(out *HTTPCheckRule) {
+	*out = *in
+	if in.Connect != nil {
+		in, out := &in.Connect, &out.Connect
+		*out = new(CheckConnect)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]CheckHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expect != nil {
+		in, out := &in.Expect, &out.Expect
+		*out = new(CheckExpect)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPCheckRule.
+func (in *HTTPCheckRule) DeepCopy() *HTTPCheckRule {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPCheckRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPChk) DeepCopyInto(out *HTTPChk) {
 	*out = *in
@@ -900,6 +1682,26 @@ func (in *HTTPReturnContent) DeepCopy() *HTTPReturnContent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSRedirect) DeepCopyInto(out *HTTPSRedirect) {
+	*out = *in
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSRedirect.
+func (in *HTTPSRedirect) DeepCopy() *HTTPSRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HashType) DeepCopyInto(out *HashType) {
 	*out = *in
@@ -910,7 +1712,22 @@ func (in *HashType) DeepCopy() *HashType {
 	if in == nil {
 		return nil
 	}
-	out := new(HashType)
+	out := new(HashType)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderCapture) DeepCopyInto(out *HeaderCapture) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderCapture.
+func (in *HeaderCapture) DeepCopy() *HeaderCapture {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderCapture)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -966,7 +1783,7 @@ func (in *Listen) DeepCopyInto(out *Listen) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Listen.
@@ -1023,6 +1840,7 @@ func (in *ListenList) DeepCopyObject() runtime.Object {
 func (in *ListenSpec) DeepCopyInto(out *ListenSpec) {
 	*out = *in
 	in.BaseSpec.DeepCopyInto(&out.BaseSpec)
+	in.ProxyLogging.DeepCopyInto(&out.ProxyLogging)
 	if in.Binds != nil {
 		in, out := &in.Binds, &out.Binds
 		*out = make([]Bind, len(*in))
@@ -1052,13 +1870,33 @@ func (in *ListenSpec) DeepCopyInto(out *ListenSpec) {
 	if in.Balance != nil {
 		in, out := &in.Balance, &out.Balance
 		*out = new(Balance)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Redispatch != nil {
 		in, out := &in.Redispatch, &out.Redispatch
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RedispatchInterval != nil {
+		in, out := &in.RedispatchInterval, &out.RedispatchInterval
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AbortOnClose != nil {
+		in, out := &in.AbortOnClose, &out.AbortOnClose
+		*out = new(bool)
+		**out = **in
+	}
 	if in.HashType != nil {
 		in, out := &in.HashType, &out.HashType
 		*out = new(HashType)
@@ -1084,6 +1922,30 @@ func (in *ListenSpec) DeepCopyInto(out *ListenSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HTTPCheckRules != nil {
+		in, out := &in.HTTPCheckRules, &out.HTTPCheckRules
+		*out = make([]HTTPCheckRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TCPCheckRules != nil {
+		in, out := &in.TCPCheckRules, &out.TCPCheckRules
+		*out = make([]TCPCheckRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FullConn != nil {
+		in, out := &in.FullConn, &out.FullConn
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AllBackups != nil {
+		in, out := &in.AllBackups, &out.AllBackups
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenSpec.
@@ -1096,6 +1958,56 @@ func (in *ListenSpec) DeepCopy() *ListenSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogField) DeepCopyInto(out *LogField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogField.
+func (in *LogField) DeepCopy() *LogField {
+	if in == nil {
+		return nil
+	}
+	out := new(LogField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogFormat) DeepCopyInto(out *LogFormat) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]LogField, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogFormat.
+func (in *LogFormat) DeepCopy() *LogFormat {
+	if in == nil {
+		return nil
+	}
+	out := new(LogFormat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogTarget) DeepCopyInto(out *LogTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogTarget.
+func (in *LogTarget) DeepCopy() *LogTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(LogTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Nameserver) DeepCopyInto(out *Nameserver) {
 	*out = *in
@@ -1131,6 +2043,41 @@ func (in *OcspFile) DeepCopy() *OcspFile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyLogging) DeepCopyInto(out *ProxyLogging) {
+	*out = *in
+	if in.LogFormat != nil {
+		in, out := &in.LogFormat, &out.LogFormat
+		*out = new(LogFormat)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogTargets != nil {
+		in, out := &in.LogTargets, &out.LogTargets
+		*out = make([]LogTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Captures != nil {
+		in, out := &in.Captures, &out.Captures
+		*out = new(Captures)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UniqueID != nil {
+		in, out := &in.UniqueID, &out.UniqueID
+		*out = new(UniqueID)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyLogging.
+func (in *ProxyLogging) DeepCopy() *ProxyLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyProtocol) DeepCopyInto(out *ProxyProtocol) {
 	*out = *in
@@ -1268,6 +2215,119 @@ func (in *RedirectType) DeepCopy() *RedirectType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrant) DeepCopyInto(out *ReferenceGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrant.
+func (in *ReferenceGrant) DeepCopy() *ReferenceGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantFrom) DeepCopyInto(out *ReferenceGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantFrom.
+func (in *ReferenceGrantFrom) DeepCopy() *ReferenceGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantList) DeepCopyInto(out *ReferenceGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReferenceGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantList.
+func (in *ReferenceGrantList) DeepCopy() *ReferenceGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantSpec) DeepCopyInto(out *ReferenceGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]ReferenceGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]ReferenceGrantTo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantSpec.
+func (in *ReferenceGrantSpec) DeepCopy() *ReferenceGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantTo) DeepCopyInto(out *ReferenceGrantTo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantTo.
+func (in *ReferenceGrantTo) DeepCopy() *ReferenceGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegexBackendMapping) DeepCopyInto(out *RegexBackendMapping) {
 	*out = *in
@@ -1306,7 +2366,7 @@ func (in *Resolver) DeepCopyInto(out *Resolver) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resolver.
@@ -1432,11 +2492,26 @@ func (in *SSL) DeepCopyInto(out *SSL) {
 		*out = new(SSLCertificate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CrtStore != nil {
+		in, out := &in.CrtStore, &out.CrtStore
+		*out = new(CrtStoreReference)
+		**out = **in
+	}
 	if in.Alpn != nil {
 		in, out := &in.Alpn, &out.Alpn
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CRLFile != nil {
+		in, out := &in.CRLFile, &out.CRLFile
+		*out = new(SSLCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CAVerifyFile != nil {
+		in, out := &in.CAVerifyFile, &out.CAVerifyFile
+		*out = new(SSLCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSL.
@@ -1464,6 +2539,11 @@ func (in *SSLCertificate) DeepCopyInto(out *SSLCertificate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertificate.
@@ -1556,6 +2636,11 @@ func (in *ServerParams) DeepCopyInto(out *ServerParams) {
 		*out = new(Check)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AgentCheck != nil {
+		in, out := &in.AgentCheck, &out.AgentCheck
+		*out = new(AgentCheck)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InitAddr != nil {
 		in, out := &in.InitAddr, &out.InitAddr
 		*out = new(string)
@@ -1576,6 +2661,56 @@ func (in *ServerParams) DeepCopyInto(out *ServerParams) {
 		*out = new(ProxyProtocol)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxConn != nil {
+		in, out := &in.MaxConn, &out.MaxConn
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxQueue != nil {
+		in, out := &in.MaxQueue, &out.MaxQueue
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinConn != nil {
+		in, out := &in.MinConn, &out.MinConn
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SlowStart != nil {
+		in, out := &in.SlowStart, &out.SlowStart
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ErrorLimit != nil {
+		in, out := &in.ErrorLimit, &out.ErrorLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PoolMaxConn != nil {
+		in, out := &in.PoolMaxConn, &out.PoolMaxConn
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PoolLowConn != nil {
+		in, out := &in.PoolLowConn, &out.PoolLowConn
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PoolPurgeDelay != nil {
+		in, out := &in.PoolPurgeDelay, &out.PoolPurgeDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerParams.
@@ -1609,6 +2744,22 @@ func (in *ServerTemplate) DeepCopy() *ServerTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceReference) DeepCopyInto(out *ServiceReference) {
+	*out = *in
+	in.ServerParams.DeepCopyInto(&out.ServerParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceReference.
+func (in *ServiceReference) DeepCopy() *ServiceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticHTTPFile) DeepCopyInto(out *StaticHTTPFile) {
 	*out = *in
@@ -1633,6 +2784,20 @@ func (in *StaticHTTPFile) DeepCopy() *StaticHTTPFile {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Status) DeepCopyInto(out *Status) {
 	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Status.
@@ -1645,6 +2810,31 @@ func (in *Status) DeepCopy() *Status {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPCheckRule) DeepCopyInto(out *TCPCheckRule) {
+	*out = *in
+	if in.Connect != nil {
+		in, out := &in.Connect, &out.Connect
+		*out = new(CheckConnect)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expect != nil {
+		in, out := &in.Expect, &out.Expect
+		*out = new(CheckExpect)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPCheckRule.
+func (in *TCPCheckRule) DeepCopy() *TCPCheckRule {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPCheckRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TCPRequestRule) DeepCopyInto(out *TCPRequestRule) {
 	*out = *in
@@ -1695,3 +2885,18 @@ func (in *Timeouts) DeepCopy() *Timeouts {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UniqueID) DeepCopyInto(out *UniqueID) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UniqueID.
+func (in *UniqueID) DeepCopy() *UniqueID {
+	if in == nil {
+		return nil
+	}
+	out := new(UniqueID)
+	in.DeepCopyInto(out)
+	return out
+}
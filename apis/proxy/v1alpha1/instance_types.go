@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net"
+	"slices"
 	"strings"
 	"time"
 
@@ -63,6 +65,18 @@ type InstanceSpec struct {
 	// +optional
 	// +nullable
 	Metrics *Metrics `json:"metrics,omitempty"`
+	// Health renders a dedicated health frontend with a monitor-uri, which is used by the default readiness and
+	// liveness probes.
+	// +optional
+	Health *Health `json:"health,omitempty"`
+	// Tracing sends OpenTelemetry traces of the requests of the selected frontends, listens and backends to an OTLP
+	// collector. It requires an image which includes the OpenTelemetry filter.
+	// +optional
+	Tracing *Tracing `json:"tracing,omitempty"`
+	// CertificateExpiryWarning is the time before the expiry of a loaded certificate at which the CertificateWarning
+	// condition is raised on the owning object. Default is 30 days.
+	// +optional
+	CertificateExpiryWarning *metav1.Duration `json:"certificateExpiryWarning,omitempty"`
 	// Labels additional labels for the ha-proxy pods
 	// +optional
 	// +nullable
@@ -85,6 +99,31 @@ type InstanceSpec struct {
 	PodDisruptionBudget PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
 }
 
+type Tracing struct {
+	// Endpoint is the URL of the OTLP/HTTP traces endpoint of the collector, e.g.
+	// http://otel-collector:4318/v1/traces.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+	// SamplingRate is the percentage of traced requests.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=100
+	// +optional
+	SamplingRate *int32 `json:"samplingRate,omitempty"`
+	// ServiceName is the service.name resource attribute of the spans.
+	// +kubebuilder:default=haproxy
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+	// ResourceAttributes are added to the resource of the spans, e.g. deployment.environment.
+	// +optional
+	ResourceAttributes map[string]string `json:"resourceAttributes,omitempty"`
+	// LabelSelector selects the frontends, listens and backends with the OpenTelemetry filter. All frontends and
+	// listens are traced if it is not set. A listen is traced in its frontend. A request is traced once for each
+	// selected section it passes, so either the frontend or the backend of a request should be selected.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
 type Placement struct {
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// +optional
@@ -210,6 +249,111 @@ func (m *Metrics) AddToParser(p parser.Parser) error {
 	return nil
 }
 
+type Health struct {
+	// Enabled will render the health frontend and add default probes if readinessProbe or livenessProbe are not set.
+	// The name 'health' cannot be used by other configuration objects then.
+	Enabled bool `json:"enabled"`
+	// Address to bind the health endpoint (default: '0.0.0.0'). Loopback addresses require readinessProbe and
+	// livenessProbe, as the default probes connect to the address of the pod.
+	// +optional
+	// +kubebuilder:default="0.0.0.0"
+	Address *string `json:"address,omitempty"`
+	// Port specifies the port used for the health endpoint.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=8405
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// URI is the monitor-uri answered by HAProxy with 200, or with 503 if a fail condition is true.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +kubebuilder:default="/healthz"
+	// +optional
+	URI string `json:"uri,omitempty"`
+	// FailIfNoServersUp reports the instance as unhealthy if one of these backends has no usable server. The backends
+	// must be part of the configuration.
+	// +optional
+	FailIfNoServersUp []string `json:"failIfNoServersUp,omitempty"`
+	// FailConditions are additional ACL conditions, e.g. "{ nbsrv(api) lt 2 }", which report the instance as
+	// unhealthy if one of them is true.
+	// +optional
+	FailConditions []string `json:"failConditions,omitempty"`
+}
+
+const (
+	// defaultHealthPort is the port of the health frontend if none is specified.
+	defaultHealthPort = 8405
+	// defaultHealthURI is the monitor-uri of the health frontend if none is specified.
+	defaultHealthURI = "/healthz"
+)
+
+// BindPort returns the port of the health frontend.
+func (h *Health) BindPort() int32 {
+	if h.Port == 0 {
+		return defaultHealthPort
+	}
+	return h.Port
+}
+
+// MonitorURI returns the monitor-uri of the health frontend.
+func (h *Health) MonitorURI() string {
+	if h.URI == "" {
+		return defaultHealthURI
+	}
+	return h.URI
+}
+
+// AddToParser renders the health frontend. It is added after all other sections, so the backends of
+// FailIfNoServersUp are checked against the rendered backends.
+func (h *Health) AddToParser(p parser.Parser) error {
+	if !h.Enabled {
+		return nil
+	}
+
+	// the section type is reported as missing if there is no backend, which leaves the list empty
+	backends, _ := p.SectionsGet(parser.Backends)
+
+	frontend := models.Frontend{
+		FrontendBase: models.FrontendBase{
+			Name:       "health",
+			Mode:       "http",
+			MonitorURI: models.MonitorURI(h.MonitorURI()),
+		},
+	}
+
+	var conditions []string
+	for _, backend := range h.FailIfNoServersUp {
+		if !slices.Contains(backends, backend) {
+			return fmt.Errorf("backend %s of failIfNoServersUp not found", backend)
+		}
+		conditions = append(conditions, fmt.Sprintf("{ nbsrv(%s) lt 1 }", backend))
+	}
+	conditions = append(conditions, h.FailConditions...)
+	if len(conditions) > 0 {
+		frontend.MonitorFail = &models.MonitorFail{
+			Cond:     ptr.To("if"),
+			CondTest: ptr.To(strings.Join(conditions, " || ")),
+		}
+	}
+
+	if err := p.SectionsCreate(parser.Frontends, frontend.Name); err != nil {
+		return err
+	}
+	configOpts := &options.ConfigurationOptions{}
+	if err := configuration.CreateEditSection(frontend.FrontendBase, parser.Frontends, frontend.Name, p, configOpts); err != nil {
+		return err
+	}
+
+	bind := models.Bind{
+		BindParams: models.BindParams{
+			Name: "health",
+		},
+		Port:    ptr.To(int64(h.BindPort())),
+		Address: ptr.Deref(h.Address, "0.0.0.0"),
+	}
+	configOpts = &options.ConfigurationOptions{}
+	return p.Insert(parser.Frontends, frontend.Name, "bind", configuration.SerializeBind(bind, configOpts), 0)
+}
+
 type Configuration struct {
 	// Global contains the global HAProxy configuration settings
 	Global GlobalConfiguration `json:"global"`
@@ -275,6 +419,120 @@ type GlobalConfiguration struct {
 	// Ocsp is used to enable stapling at the global level for all certificates in the configuration.
 	// +optional
 	Ocsp *GlobalOCSPConfiguration `json:"ocsp,omitempty"`
+	// RuntimeAPI exposes the HAProxy Runtime API on a TCP port. If it is bound to a pod address, the operator uses
+	// it to update servers discovered from EndpointSlices, bind and server certificates and crt-list entries
+	// without a rollout.
+	// +optional
+	RuntimeAPI *RuntimeAPIConfiguration `json:"runtimeAPI,omitempty"`
+	// ACME declares providers issuing the certificates of crt-list elements with an acme reference. The operator
+	// writes issued certificates back into Secrets using the RuntimeAPI bound to a pod address, so new replicas
+	// start with them. It requires HAProxy 3.2 or later.
+	// +optional
+	ACME []ACMEProvider `json:"acme,omitempty"`
+	// TLSTicketKeys generates TLS session ticket keys shared by all replicas and rotates them. Binds use them with
+	// ssl.tlsTicketKeys. If the RuntimeAPI is bound to a pod address, rotated keys are pushed to the running pods,
+	// otherwise they are loaded with the next rollout.
+	// +optional
+	TLSTicketKeys *TLSTicketKeysConfiguration `json:"tlsTicketKeys,omitempty"`
+}
+
+type TLSTicketKeysConfiguration struct {
+	// RotationInterval is the interval in which a new key is generated. The two previous keys are kept to decrypt
+	// tickets issued before the rotation.
+	// +kubebuilder:default="12h"
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+}
+
+// Interval returns the rotation interval of the keys.
+func (t *TLSTicketKeysConfiguration) Interval() time.Duration {
+	if t.RotationInterval == nil {
+		return 12 * time.Hour
+	}
+
+	return t.RotationInterval.Duration
+}
+
+type ACMEProvider struct {
+	// Name of the provider, referenced by crt-list elements.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.:-]+$`
+	Name string `json:"name"`
+	// Directory is the URL of the ACME directory, e.g. https://acme-v02.api.letsencrypt.org/directory or the
+	// directory of a pebble test server.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Directory string `json:"directory"`
+	// AccountKey selects a Secret key containing the PEM encoded private key of the ACME account.
+	AccountKey corev1.SecretKeySelector `json:"accountKey"`
+	// Contact is the e-mail address registered with the ACME account.
+	// +optional
+	Contact string `json:"contact,omitempty"`
+	// Challenge is the type of challenge used to validate the domains. HTTP-01 challenge tokens are stored in the
+	// virt@acme map and must be answered by a frontend listening on port 80, e.g. with an http-request return
+	// rule matching the path prefix /.well-known/acme-challenge/.
+	// +kubebuilder:validation:Enum=HTTP-01;DNS-01
+	// +kubebuilder:default=HTTP-01
+	// +optional
+	Challenge string `json:"challenge,omitempty"`
+	// KeyType is the type of the private keys generated for issued certificates.
+	// +kubebuilder:validation:Enum=RSA;ECDSA
+	// +optional
+	KeyType string `json:"keyType,omitempty"`
+}
+
+func (a *ACMEProvider) AccountKeyFilePath() string {
+	return fmt.Sprintf("/usr/local/etc/haproxy/%s.account.key", a.Name)
+}
+
+func (a *ACMEProvider) Model() (models.AcmeProvider, error) {
+	model := models.AcmeProvider{
+		Name:       a.Name,
+		Directory:  a.Directory,
+		AccountKey: a.AccountKeyFilePath(),
+		Contact:    a.Contact,
+		Challenge:  a.Challenge,
+		Keytype:    a.KeyType,
+		Map:        "virt@acme",
+	}
+
+	return model, model.Validate(strfmt.Default)
+}
+
+// ACMEProvider returns the ACME provider with the given name.
+func (g *GlobalConfiguration) ACMEProvider(name string) (*ACMEProvider, bool) {
+	for i := range g.ACME {
+		if g.ACME[i].Name == name {
+			return &g.ACME[i], true
+		}
+	}
+
+	return nil, false
+}
+
+type RuntimeAPIConfiguration struct {
+	// Address to bind the Runtime API (default: '127.0.0.1'). The Runtime API has no authentication and allows to
+	// change servers and to dump private keys, so by default it is only reachable from within the pod. The operator
+	// only uses it if it is bound to a pod address, e.g. '0.0.0.0'. A NetworkPolicy then restricts access to the
+	// port to the operator.
+	// +optional
+	// +kubebuilder:default="127.0.0.1"
+	// +kubebuilder:validation:Format=ipv4
+	Address *string `json:"address,omitempty"`
+	// Port specifies the TCP port of the Runtime API.
+	// +kubebuilder:default=9999
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// SocketAddress returns the address of the stats socket exposing the Runtime API.
+func (r *RuntimeAPIConfiguration) SocketAddress() string {
+	return fmt.Sprintf("ipv4@%s:%d", ptr.Deref(r.Address, "127.0.0.1"), r.Port)
+}
+
+// Remote returns true if the Runtime API is bound to a pod address, so the operator can reach it.
+func (r *RuntimeAPIConfiguration) Remote() bool {
+	ip := net.ParseIP(ptr.Deref(r.Address, "127.0.0.1"))
+	return ip != nil && !ip.IsLoopback()
 }
 
 func (g *GlobalConfiguration) Model() (models.Global, error) {
@@ -314,6 +572,20 @@ func (g *GlobalConfiguration) Model() (models.Global, error) {
 		})
 	}
 
+	// acme sections are experimental in HAProxy 3.2
+	if len(g.ACME) > 0 {
+		global.ExposeExperimentalDirectives = true
+	}
+
+	if g.RuntimeAPI != nil {
+		global.RuntimeAPIs = append(global.RuntimeAPIs, &models.RuntimeAPI{
+			Address: ptr.To(g.RuntimeAPI.SocketAddress()),
+			BindParams: models.BindParams{
+				Level: "admin",
+			},
+		})
+	}
+
 	if g.TuneOptions != nil {
 		opts, err := g.TuneOptions.Model()
 		if err != nil {
@@ -334,6 +606,14 @@ func (g *GlobalConfiguration) Model() (models.Global, error) {
 			}
 			global.TuneSslOptions = &optsSSL
 		}
+
+		if g.TuneOptions.QUIC != nil {
+			optsQUIC, errQUIC := g.TuneOptions.ModelTuneQuicOptions()
+			if errQUIC != nil {
+				return global, errQUIC
+			}
+			global.TuneQuicOptions = &optsQUIC
+		}
 	}
 
 	if g.Ocsp != nil {
@@ -394,6 +674,20 @@ func (g *GlobalConfiguration) AddToParser(p parser.Parser) error {
 		}
 	}
 
+	for _, provider := range g.ACME {
+		model, err := provider.Model()
+		if err != nil {
+			return err
+		}
+
+		if err := p.SectionsCreate(parser.Acme, provider.Name); err != nil {
+			return err
+		}
+		if err := configuration.SerializeAcmeProvider(p, &model); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -444,6 +738,39 @@ type GlobalTuneOptions struct {
 	// SSL sets the SSL tune options.
 	// +optional
 	SSL *GlobalSSLTuneOptions `json:"ssl,omitempty"`
+	// QUIC sets the QUIC tune options used by QUIC binds.
+	// +optional
+	QUIC *GlobalQUICTuneOptions `json:"quic,omitempty"`
+}
+
+type GlobalQUICTuneOptions struct {
+	// FrontendConnTxBuffersLimit sets the maximum number of send buffers allocated per QUIC connection.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FrontendConnTxBuffersLimit *int64 `json:"frontendConnTxBuffersLimit,omitempty"`
+	// FrontendMaxIdleTimeout sets the QUIC max_idle_timeout transport parameter. Connections without activity
+	// are closed after this time. The default is 30s.
+	// +optional
+	FrontendMaxIdleTimeout *metav1.Duration `json:"frontendMaxIdleTimeout,omitempty"`
+	// FrontendMaxStreamsBidi sets the number of concurrent bidirectional streams a client may open on a
+	// connection. The default is 100.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FrontendMaxStreamsBidi *int64 `json:"frontendMaxStreamsBidi,omitempty"`
+	// MaxFrameLoss sets the number of times a frame may be lost before the connection is closed. The default is 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxFrameLoss *int64 `json:"maxFrameLoss,omitempty"`
+	// RetryThreshold sets the number of half-open connections above which Retry packets are sent to validate
+	// client addresses. The default is 100.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RetryThreshold *int64 `json:"retryThreshold,omitempty"`
+	// SocketOwner defines whether QUIC connections share the listener socket or get a dedicated socket.
+	// Dedicated sockets perform better but require one file descriptor per connection.
+	// +kubebuilder:validation:Enum=listener;connection
+	// +optional
+	SocketOwner string `json:"socketOwner,omitempty"`
 }
 
 type GlobalSSLTuneOptions struct {
@@ -526,6 +853,22 @@ func (t *GlobalTuneOptions) ModelTuneSSLOptions() (models.TuneSslOptions, error)
 	return opts, opts.Validate(strfmt.Default)
 }
 
+func (t *GlobalTuneOptions) ModelTuneQuicOptions() (models.TuneQuicOptions, error) {
+	opts := models.TuneQuicOptions{
+		FrontendConnTxBuffersLimit: t.QUIC.FrontendConnTxBuffersLimit,
+		FrontendMaxStreamsBidi:     t.QUIC.FrontendMaxStreamsBidi,
+		MaxFrameLoss:               t.QUIC.MaxFrameLoss,
+		RetryThreshold:             t.QUIC.RetryThreshold,
+		SocketOwner:                t.QUIC.SocketOwner,
+	}
+
+	if t.QUIC.FrontendMaxIdleTimeout != nil {
+		opts.FrontendMaxIdleTimeout = ptr.To(t.QUIC.FrontendMaxIdleTimeout.Milliseconds())
+	}
+
+	return opts, opts.Validate(strfmt.Default)
+}
+
 type GlobalLoggingConfiguration struct {
 	// Enabled will toggle the creation of a global syslog server.
 	Enabled bool `json:"enabled"`
@@ -747,6 +1090,12 @@ type InstanceStatus struct {
 	// Error shows the actual error message if Phase is 'Error'.
 	// +optional
 	Error string `json:"error,omitempty"`
+	// Certificates lists the additional certificates and ACME account keys loaded for the instance.
+	// +optional
+	Certificates []configv1alpha1.CertificateStatus `json:"certificates,omitempty"`
+	// Conditions represent the latest available observations of the instance.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // InstancePhase is a label for the phase of a Instance at the current time.
@@ -31,6 +31,22 @@ import (
 	timex "time"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEProvider) DeepCopyInto(out *ACMEProvider) {
+	*out = *in
+	in.AccountKey.DeepCopyInto(&out.AccountKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEProvider.
+func (in *ACMEProvider) DeepCopy() *ACMEProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
@@ -162,6 +178,23 @@ func (in *GlobalConfiguration) DeepCopyInto(out *GlobalConfiguration) {
 		*out = new(GlobalOCSPConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuntimeAPI != nil {
+		in, out := &in.RuntimeAPI, &out.RuntimeAPI
+		*out = new(RuntimeAPIConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ACME != nil {
+		in, out := &in.ACME, &out.ACME
+		*out = make([]ACMEProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLSTicketKeys != nil {
+		in, out := &in.TLSTicketKeys, &out.TLSTicketKeys
+		*out = new(TLSTicketKeysConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalConfiguration.
@@ -229,6 +262,46 @@ func (in *GlobalOCSPConfiguration) DeepCopy() *GlobalOCSPConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalQUICTuneOptions) DeepCopyInto(out *GlobalQUICTuneOptions) {
+	*out = *in
+	if in.FrontendConnTxBuffersLimit != nil {
+		in, out := &in.FrontendConnTxBuffersLimit, &out.FrontendConnTxBuffersLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FrontendMaxIdleTimeout != nil {
+		in, out := &in.FrontendMaxIdleTimeout, &out.FrontendMaxIdleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FrontendMaxStreamsBidi != nil {
+		in, out := &in.FrontendMaxStreamsBidi, &out.FrontendMaxStreamsBidi
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxFrameLoss != nil {
+		in, out := &in.MaxFrameLoss, &out.MaxFrameLoss
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RetryThreshold != nil {
+		in, out := &in.RetryThreshold, &out.RetryThreshold
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalQUICTuneOptions.
+func (in *GlobalQUICTuneOptions) DeepCopy() *GlobalQUICTuneOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalQUICTuneOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalSSL) DeepCopyInto(out *GlobalSSL) {
 	*out = *in
@@ -337,6 +410,11 @@ func (in *GlobalTuneOptions) DeepCopyInto(out *GlobalTuneOptions) {
 		*out = new(GlobalSSLTuneOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.QUIC != nil {
+		in, out := &in.QUIC, &out.QUIC
+		*out = new(GlobalQUICTuneOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalTuneOptions.
@@ -349,13 +427,43 @@ func (in *GlobalTuneOptions) DeepCopy() *GlobalTuneOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Health) DeepCopyInto(out *Health) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailIfNoServersUp != nil {
+		in, out := &in.FailIfNoServersUp, &out.FailIfNoServersUp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailConditions != nil {
+		in, out := &in.FailConditions, &out.FailConditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Health.
+func (in *Health) DeepCopy() *Health {
+	if in == nil {
+		return nil
+	}
+	out := new(Health)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Instance.
@@ -445,6 +553,21 @@ func (in *InstanceSpec) DeepCopyInto(out *InstanceSpec) {
 		*out = new(Metrics)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(Health)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateExpiryWarning != nil {
+		in, out := &in.CertificateExpiryWarning, &out.CertificateExpiryWarning
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -485,6 +608,20 @@ func (in *InstanceSpec) DeepCopy() *InstanceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
 	*out = *in
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]configv1alpha1.CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceStatus.
@@ -642,6 +779,26 @@ func (in *RouteSpec) DeepCopy() *RouteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeAPIConfiguration) DeepCopyInto(out *RuntimeAPIConfiguration) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeAPIConfiguration.
+func (in *RuntimeAPIConfiguration) DeepCopy() *RuntimeAPIConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeAPIConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
@@ -668,3 +825,55 @@ func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSTicketKeysConfiguration) DeepCopyInto(out *TLSTicketKeysConfiguration) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSTicketKeysConfiguration.
+func (in *TLSTicketKeysConfiguration) DeepCopy() *TLSTicketKeysConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSTicketKeysConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	if in.SamplingRate != nil {
+		in, out := &in.SamplingRate, &out.SamplingRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ResourceAttributes != nil {
+		in, out := &in.ResourceAttributes, &out.ResourceAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,336 @@
+package instance
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/certs"
+	"github.com/six-group/haproxy-operator/pkg/runtimeapi"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// acmePlaceholderAnnotation marks Secrets holding the self-signed certificate served until ACME issued the certificate.
+const acmePlaceholderAnnotation = "proxy.haproxy.com/acme-placeholder"
+
+// acmeRenewedAnnotation records on the placeholder Secret when the issuance of the certificate was last started.
+const acmeRenewedAnnotation = "proxy.haproxy.com/acme-renewed"
+
+// acmeRenewBackoff is the time after which the issuance of a still pending ACME certificate is started again.
+const acmeRenewBackoff = 15 * time.Minute
+
+// placeholderCertificateUnit is the organizational unit of temporary self-signed certificates, which are served
+// until the certificate is issued.
+const placeholderCertificateUnit = "haproxy-operator placeholder"
+
+// placeholderCertificateValidity is the validity of temporary self-signed certificates. They are regenerated once
+// they expire within placeholderCertificateRenewBefore, as issuing the certificate may take longer.
+const (
+	placeholderCertificateValidity    = 24 * time.Hour
+	placeholderCertificateRenewBefore = time.Hour
+)
+
+func (r *Reconciler) loadACMEAccountKey(ctx context.Context, instance *proxyv1alpha1.Instance, provider proxyv1alpha1.ACMEProvider) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: provider.AccountKey.Name, Namespace: instance.Namespace}, secret); err != nil {
+		return "", err
+	}
+
+	data, ok := secret.Data[provider.AccountKey.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in ACME account key secret: %s/%s", provider.AccountKey.Key, instance.Namespace, provider.AccountKey.Name)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// loadACMECertificateData loads the certificate of a crt-list element issued by ACME from the Secret it is persisted
+// in. If the Secret does not exist, it is created with a self-signed certificate served until the certificate is issued.
+// The self-signed certificate is regenerated before it expires.
+func (r *Reconciler) loadACMECertificateData(ctx context.Context, instance *proxyv1alpha1.Instance, element configv1alpha1.CertificateListElement) (string, error) {
+	if _, ok := instance.Spec.Configuration.Global.ACMEProvider(element.ACME.Provider); !ok {
+		return "", fmt.Errorf("acme provider %s of certificate %s not found", element.ACME.Provider, element.Certificate.Name)
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Name: utils.GetCertificateName(instance, &element.Certificate), Namespace: instance.Namespace}, secret)
+	switch {
+	case errors.IsNotFound(err):
+		secret, err = r.createACMEPlaceholderSecret(ctx, instance, element)
+	case err == nil && secret.Annotations[acmePlaceholderAnnotation] == "true" && placeholderExpiring(secret.Data[corev1.TLSCertKey], time.Now()):
+		err = r.renewACMEPlaceholderSecret(ctx, secret, element)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{strings.TrimSpace(string(secret.Data[corev1.TLSCertKey])), strings.TrimSpace(string(secret.Data[corev1.TLSPrivateKeyKey]))}, "\n"), nil
+}
+
+func (r *Reconciler) createACMEPlaceholderSecret(ctx context.Context, instance *proxyv1alpha1.Instance, element configv1alpha1.CertificateListElement) (*corev1.Secret, error) {
+	certificate, key, err := selfSignedCertificate(element.ACME.Domains)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        utils.GetCertificateName(instance, &element.Certificate),
+			Namespace:   instance.Namespace,
+			Labels:      utils.GetAppSelectorLabels(instance),
+			Annotations: map[string]string{acmePlaceholderAnnotation: "true"},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certificate,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}
+	if err := controllerutil.SetOwnerReference(instance, secret, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	return secret, r.Create(ctx, secret)
+}
+
+// renewACMEPlaceholderSecret replaces the expiring self-signed certificate of a placeholder Secret.
+func (r *Reconciler) renewACMEPlaceholderSecret(ctx context.Context, secret *corev1.Secret, element configv1alpha1.CertificateListElement) error {
+	certificate, key, err := selfSignedCertificate(element.ACME.Domains)
+	if err != nil {
+		return err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       certificate,
+		corev1.TLSPrivateKeyKey: key,
+	}
+
+	return r.Update(ctx, secret)
+}
+
+// reconcileACMECertificates persists certificates issued by ACME into their Secrets and starts the issuance of
+// certificates which are still self-signed. Only the first running pod issues certificates, the others receive
+// them from the updated Secret using the Runtime API. It returns true while certificates are pending. Failures are
+// only logged.
+func (r *Reconciler) reconcileACMECertificates(ctx context.Context, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) bool {
+	logger := log.FromContext(ctx)
+
+	runtimeAPI := operatorRuntimeAPI(instance)
+	if runtimeAPI == nil || len(instance.Spec.Configuration.Global.ACME) == 0 {
+		return false
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(instance.Namespace), client.MatchingLabels(utils.GetAppSelectorLabels(instance))); err != nil {
+		logger.Error(err, "Unable to list pods")
+		return false
+	}
+
+	running := slices.DeleteFunc(pods.Items, func(pod corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == ""
+	})
+	if len(running) == 0 {
+		return false
+	}
+	slices.SortFunc(running, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	c := runtimeapi.NewClient(net.JoinHostPort(running[0].Status.PodIP, strconv.Itoa(int(runtimeAPI.Port))))
+
+	var pending bool
+	for _, element := range acmeCertificateListElements(listens, frontends, backends) {
+		issued, err := r.syncACMECertificate(ctx, c, instance, element)
+		if err != nil {
+			logger.Error(err, "Unable to sync ACME certificate", "pod", running[0].Name, "certificate", element.Certificate.Name)
+		}
+		pending = pending || !issued
+	}
+
+	return pending
+}
+
+// syncACMECertificate writes the certificate loaded by HAProxy into the Secret if it is newer and returns whether
+// the certificate is issued.
+func (r *Reconciler) syncACMECertificate(ctx context.Context, c *runtimeapi.Client, instance *proxyv1alpha1.Instance, element configv1alpha1.CertificateListElement) (bool, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: utils.GetCertificateName(instance, &element.Certificate), Namespace: instance.Namespace}, secret); err != nil {
+		return false, err
+	}
+	placeholder := secret.Annotations[acmePlaceholderAnnotation] == "true"
+
+	dump, err := c.DumpSSLCertificate(element.Certificate.FilePath())
+	if err != nil {
+		return !placeholder, err
+	}
+
+	certificates, key := splitPEM([]byte(dump))
+	loaded, err := leafNotAfter(certificates)
+	if err != nil {
+		return !placeholder, err
+	}
+	stored, err := leafNotAfter(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return !placeholder, err
+	}
+
+	if loaded.After(stored) && len(key) > 0 {
+		secret.Data[corev1.TLSCertKey] = certificates
+		secret.Data[corev1.TLSPrivateKeyKey] = key
+		delete(secret.Annotations, acmePlaceholderAnnotation)
+		delete(secret.Annotations, acmeRenewedAnnotation)
+		return true, r.Update(ctx, secret)
+	}
+
+	if placeholder {
+		return false, r.renewACMECertificate(ctx, c, secret, element)
+	}
+
+	return true, nil
+}
+
+// renewACMECertificate starts the issuance of a pending certificate. HAProxy completes the challenges in the
+// background, so it is only started again after a failure was reported or the backoff passed.
+func (r *Reconciler) renewACMECertificate(ctx context.Context, c *runtimeapi.Client, secret *corev1.Secret, element configv1alpha1.CertificateListElement) error {
+	if renewed, err := time.Parse(time.RFC3339, secret.Annotations[acmeRenewedAnnotation]); err == nil && time.Since(renewed) < acmeRenewBackoff {
+		return nil
+	}
+
+	if err := c.RenewACMECertificate(element.Certificate.FilePath()); err != nil {
+		return err
+	}
+
+	secret.Annotations[acmeRenewedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	return r.Update(ctx, secret)
+}
+
+// acmeCertificateListElements returns the crt-list elements issued by ACME.
+func acmeCertificateListElements(listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) []configv1alpha1.CertificateListElement {
+	var elements []configv1alpha1.CertificateListElement
+
+	var binds []configv1alpha1.Bind
+	for _, listen := range listens.Items {
+		binds = append(binds, listen.Spec.Binds...)
+		if listen.Spec.HostCertificate != nil {
+			elements = append(elements, *listen.Spec.HostCertificate)
+		}
+	}
+	for _, frontend := range frontends.Items {
+		binds = append(binds, frontend.Spec.Binds...)
+	}
+	for _, backend := range backends.Items {
+		if backend.Spec.HostCertificate != nil {
+			elements = append(elements, *backend.Spec.HostCertificate)
+		}
+	}
+	for _, bind := range binds {
+		if bind.SSLCertificateList != nil {
+			elements = append(elements, bind.SSLCertificateList.Elements...)
+		}
+	}
+
+	return slices.DeleteFunc(elements, func(element configv1alpha1.CertificateListElement) bool {
+		return element.ACME == nil
+	})
+}
+
+// selfSignedCertificate returns a PEM encoded self-signed placeholder certificate and private key for the domains.
+func selfSignedCertificate(domains []string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: domains[0], OrganizationalUnit: []string{placeholderCertificateUnit}},
+		DNSNames:     domains,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(placeholderCertificateValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// placeholderExpiring returns whether the PEM data is a self-signed placeholder certificate, which expires within
+// placeholderCertificateRenewBefore.
+func placeholderExpiring(data []byte, now time.Time) bool {
+	certificates, err := certs.Certificates(data)
+	if err != nil || len(certificates) == 0 {
+		return false
+	}
+
+	return slices.Contains(certificates[0].Subject.OrganizationalUnit, placeholderCertificateUnit) &&
+		now.Add(placeholderCertificateRenewBefore).After(certificates[0].NotAfter)
+}
+
+// splitPEM splits PEM data into the certificates and the private key.
+func splitPEM(data []byte) ([]byte, []byte) {
+	var certificates, key bytes.Buffer
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			_ = pem.Encode(&key, block)
+		} else if block.Type == "CERTIFICATE" {
+			_ = pem.Encode(&certificates, block)
+		}
+	}
+
+	return certificates.Bytes(), key.Bytes()
+}
+
+// leafNotAfter returns the expiry of the first certificate in the PEM data.
+func leafNotAfter(data []byte) (time.Time, error) {
+	certificates, err := certs.Certificates(data)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(certificates) == 0 {
+		return time.Time{}, fmt.Errorf("no certificate found")
+	}
+
+	return certificates[0].NotAfter, nil
+}
@@ -1,11 +1,15 @@
 package instance
 
-// InspectCluster will verify the availability of extra features available to the cluster, such as Prometheus and
-// OpenShift Routes.
+// InspectCluster will verify the availability of extra features available to the cluster, such as Prometheus,
+// OpenShift Routes and cert-manager.
 func InspectCluster() error {
 	if err := VerifyPrometheusAPI(); err != nil {
 		return err
 	}
 
+	if err := VerifyCertManagerAPI(); err != nil {
+		return err
+	}
+
 	return VerifyRouteAPI()
 }
@@ -0,0 +1,148 @@
+package instance
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/certs"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// defaultCertificateExpiryWarning is the default time before the expiry of a certificate at which a warning is raised.
+const defaultCertificateExpiryWarning = 30 * 24 * time.Hour
+
+// certificateRequeueInterval is the interval in which pending certificates are checked and failed certificate
+// updates of running pods are retried.
+const certificateRequeueInterval = time.Minute
+
+var certificateExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "haproxy_operator_certificate_expiry_seconds",
+	Help: "Expiry of the certificates loaded by the HAProxy instances as unix timestamp.",
+}, []string{"namespace", "instance", "kind", "name", "certificate"})
+
+func init() {
+	metrics.Registry.MustRegister(certificateExpiry)
+}
+
+// certificateInventory collects the certificates loaded for the instance and its configuration objects, keyed by
+// kind and name of the owning object.
+type certificateInventory map[string][]configv1alpha1.CertificateStatus
+
+func certificateInventoryKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// record parses the PEM data loaded for a certificate and adds the first certificate it contains. Data without a
+// certificate, e.g. a private key or a CRL, is ignored.
+func (i certificateInventory) record(kind, name, certificate, data string) {
+	parsed, err := certs.Certificates([]byte(data))
+	if err != nil || len(parsed) == 0 {
+		return
+	}
+	leaf := parsed[0]
+
+	status := configv1alpha1.CertificateStatus{
+		Name:     certificate,
+		Subject:  leaf.Subject.String(),
+		Issuer:   leaf.Issuer.String(),
+		DNSNames: leaf.DNSNames,
+		NotAfter: metav1.NewTime(leaf.NotAfter),
+		Pending:  slices.Contains(leaf.Subject.OrganizationalUnit, placeholderCertificateUnit),
+	}
+	if key, err := certs.PrivateKey([]byte(data)); err == nil && key != nil {
+		status.KeyMismatch = !certs.KeyMatches(leaf, key)
+	}
+
+	key := certificateInventoryKey(kind, name)
+	i[key] = append(i[key], status)
+}
+
+// pending returns true if a temporary certificate is served for a certificate which is not issued yet.
+func (i certificateInventory) pending() bool {
+	for _, certificates := range i {
+		if slices.ContainsFunc(certificates, func(certificate configv1alpha1.CertificateStatus) bool {
+			return certificate.Pending
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// certificates returns the certificates loaded for an object.
+func (i certificateInventory) certificates(kind, name string) []configv1alpha1.CertificateStatus {
+	return i[certificateInventoryKey(kind, name)]
+}
+
+// updateMetrics replaces the expiry metrics of the instance with the certificates of the inventory.
+func (i certificateInventory) updateMetrics(instance *proxyv1alpha1.Instance) {
+	deleteCertificateMetrics(instance.Namespace, instance.Name)
+
+	for key, certificates := range i {
+		kind, name, _ := strings.Cut(key, "/")
+		for _, certificate := range certificates {
+			certificateExpiry.WithLabelValues(instance.Namespace, instance.Name, kind, name, certificate.Name).Set(float64(certificate.NotAfter.Unix()))
+		}
+	}
+}
+
+// setCertificateCondition sets the CertificateWarning condition for the certificates of an object.
+func setCertificateCondition(conditions *[]metav1.Condition, instance *proxyv1alpha1.Instance, certificates []configv1alpha1.CertificateStatus, generation int64) {
+	if len(certificates) == 0 {
+		meta.RemoveStatusCondition(conditions, configv1alpha1.ConditionCertificateWarning)
+		return
+	}
+
+	window := defaultCertificateExpiryWarning
+	if instance.Spec.CertificateExpiryWarning != nil {
+		window = instance.Spec.CertificateExpiryWarning.Duration
+	}
+
+	condition := metav1.Condition{
+		Type:               configv1alpha1.ConditionCertificateWarning,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Valid",
+		Message:            "all certificates are valid",
+		ObservedGeneration: generation,
+	}
+
+	now := time.Now()
+	for _, certificate := range certificates {
+		if reason, message := certificateWarning(certificate, now, window); reason != "" {
+			condition.Status, condition.Reason, condition.Message = metav1.ConditionTrue, reason, message
+			break
+		}
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// certificateWarning returns the reason and message of a warning for a certificate, or empty strings if it is valid.
+func certificateWarning(certificate configv1alpha1.CertificateStatus, now time.Time, window time.Duration) (string, string) {
+	notAfter := certificate.NotAfter.UTC().Format(time.RFC3339)
+
+	switch {
+	case certificate.Pending:
+		return "Pending", fmt.Sprintf("certificate %s is not issued yet, a temporary certificate is served", certificate.Name)
+	case certificate.KeyMismatch:
+		return "KeyMismatch", fmt.Sprintf("certificate %s does not match its private key", certificate.Name)
+	case now.After(certificate.NotAfter.Time):
+		return "Expired", fmt.Sprintf("certificate %s expired at %s", certificate.Name, notAfter)
+	case now.Add(window).After(certificate.NotAfter.Time):
+		return "ExpiringSoon", fmt.Sprintf("certificate %s expires at %s", certificate.Name, notAfter)
+	default:
+		return "", ""
+	}
+}
+
+// deleteCertificateMetrics removes the certificate expiry series of an instance.
+func deleteCertificateMetrics(namespace, name string) {
+	certificateExpiry.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "instance": name})
+}
@@ -0,0 +1,67 @@
+package instance
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var _ = Describe("Certificates", Label("controller"), func() {
+	Context("updateMetrics", func() {
+		// series returns the number of certificate expiry series of the instance.
+		series := func(instance *proxyv1alpha1.Instance) int {
+			families, err := metrics.Registry.Gather()
+			Ω(err).ShouldNot(HaveOccurred())
+
+			count := 0
+			for _, family := range families {
+				if family.GetName() != "haproxy_operator_certificate_expiry_seconds" {
+					continue
+				}
+				for _, metric := range family.GetMetric() {
+					labels := map[string]string{}
+					for _, label := range metric.GetLabel() {
+						labels[label.GetName()] = label.GetValue()
+					}
+					if labels["namespace"] == instance.Namespace && labels["instance"] == instance.Name {
+						count++
+					}
+				}
+			}
+			return count
+		}
+
+		It("should delete the metrics of deleted instances", func() {
+			scheme := runtime.NewScheme()
+			Ω(clientgoscheme.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+			Ω(proxyv1alpha1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+
+			proxy := &proxyv1alpha1.Instance{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "foo"}}
+
+			cert, key, err := selfSignedCertificate([]string{"example.com"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			inventory := certificateInventory{}
+			inventory.record("Instance", proxy.Name, "example", string(cert)+string(key))
+			inventory.updateMetrics(proxy)
+			Ω(series(proxy)).Should(Equal(1))
+
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+				Scheme: scheme,
+			}
+			_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: proxy.Name, Namespace: proxy.Namespace}})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(series(proxy)).Should(BeZero())
+		})
+	})
+})
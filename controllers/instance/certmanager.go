@@ -0,0 +1,150 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var certManagerAPIFound = false
+
+// certManagerCertificateGVK is the cert-manager Certificate kind. It is handled as unstructured object to avoid
+// depending on the cert-manager API module.
+var certManagerCertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// newCertManagerCertificate returns an empty cert-manager Certificate.
+func newCertManagerCertificate() *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certManagerCertificateGVK)
+	return certificate
+}
+
+// loadCertManagerCertificateData creates or updates the cert-manager Certificate of an SSL certificate and returns the
+// certificate chain, the private key and the CA of the issued Secret.
+func (r *Reconciler) loadCertManagerCertificateData(ctx context.Context, instance *proxyv1alpha1.Instance, certificate *configv1alpha1.SSLCertificate) (string, error) {
+	if !IsCertManagerAPIAvailable() {
+		return "", fmt.Errorf("certificate %s requires cert-manager, but the cert-manager API is not available", certificate.Name)
+	}
+
+	name := utils.GetCertificateName(instance, certificate)
+	if err := r.reconcileCertManagerCertificate(ctx, instance, name, certificate.CertManager); err != nil {
+		return "", err
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: instance.Namespace}, secret)
+	if errors.IsNotFound(err) {
+		return r.loadPendingCertificateData(ctx, instance, certificate)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var items []string
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "ca.crt"} {
+		if data := strings.TrimSpace(string(secret.Data[key])); data != "" {
+			items = append(items, data)
+		}
+	}
+	if len(items) < 2 {
+		return r.loadPendingCertificateData(ctx, instance, certificate)
+	}
+
+	return strings.Join(items, "\n"), nil
+}
+
+// loadPendingCertificateData returns the data of a certificate which is not issued yet. The previous file of the
+// configuration Secret is kept, so a new certificate does not block other configuration changes. Without a previous
+// file a temporary self-signed certificate is served, which is reported as pending in the status and regenerated
+// before it expires.
+func (r *Reconciler) loadPendingCertificateData(ctx context.Context, instance *proxyv1alpha1.Instance, certificate *configv1alpha1.SSLCertificate) (string, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Name: utils.GetConfigSecretName(instance), Namespace: instance.Namespace}, secret)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	if data, ok := secret.Data[filepath.Base(certificate.FilePath())]; ok && !placeholderExpiring(data, time.Now()) {
+		return string(data), nil
+	}
+
+	cert, key, err := selfSignedCertificate(certificate.CertManager.DNSNames)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{strings.TrimSpace(string(cert)), strings.TrimSpace(string(key))}, "\n"), nil
+}
+
+func (r *Reconciler) reconcileCertManagerCertificate(ctx context.Context, instance *proxyv1alpha1.Instance, name string, spec *configv1alpha1.CertManagerCertificate) error {
+	logger := log.FromContext(ctx)
+
+	certificate := newCertManagerCertificate()
+	certificate.SetName(name)
+	certificate.SetNamespace(instance.Namespace)
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, certificate, func() error {
+		if err := controllerutil.SetOwnerReference(instance, certificate, r.Scheme); err != nil {
+			return err
+		}
+
+		certificate.SetLabels(utils.GetAppSelectorLabels(instance))
+
+		dnsNames := make([]any, 0, len(spec.DNSNames))
+		for _, dnsName := range spec.DNSNames {
+			dnsNames = append(dnsNames, dnsName)
+		}
+
+		issuerRef := map[string]any{
+			"name":  spec.IssuerRef.Name,
+			"kind":  utils.StringOrDefault(spec.IssuerRef.Kind, "Issuer"),
+			"group": utils.StringOrDefault(spec.IssuerRef.Group, certManagerCertificateGVK.Group),
+		}
+
+		values := map[string]any{
+			"secretName": name,
+			"dnsNames":   dnsNames,
+			"issuerRef":  issuerRef,
+		}
+		if spec.Duration != nil {
+			values["duration"] = spec.Duration.Duration.String()
+		}
+
+		return unstructured.SetNestedMap(certificate.Object, values, "spec")
+	})
+	if err != nil {
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		logger.Info(fmt.Sprintf("Object %s", result), "certificate", certificate.GetName())
+	}
+
+	return nil
+}
+
+// IsCertManagerAPIAvailable returns true if the cert-manager API is present.
+func IsCertManagerAPIAvailable() bool {
+	return certManagerAPIFound
+}
+
+// VerifyCertManagerAPI will verify that the cert-manager API is present.
+func VerifyCertManagerAPI() error {
+	found, err := utils.VerifyAPI(certManagerCertificateGVK.Group, certManagerCertificateGVK.Version)
+	if err != nil {
+		return err
+	}
+	certManagerAPIFound = found
+	return nil
+}
@@ -0,0 +1,109 @@
+package instance
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CertManager", Label("controller"), func() {
+	Context("loadCertManagerCertificateData", func() {
+		var (
+			ctx         context.Context
+			r           *Reconciler
+			proxy       *proxyv1alpha1.Instance
+			certificate *configv1alpha1.SSLCertificate
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Ω(clientgoscheme.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+			Ω(proxyv1alpha1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+
+			ctx = context.Background()
+
+			certManagerAPIFound = true
+			DeferCleanup(func() {
+				certManagerAPIFound = false
+			})
+
+			proxy = &proxyv1alpha1.Instance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar-foo",
+					Namespace: "foo",
+					UID:       uuid.NewUUID(),
+				},
+			}
+			certificate = &configv1alpha1.SSLCertificate{
+				Name: "example",
+				CertManager: &configv1alpha1.CertManagerCertificate{
+					IssuerRef: configv1alpha1.CertManagerIssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"},
+					DNSNames:  []string{"example.com"},
+				},
+			}
+
+			r = &Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(proxy).Build(),
+				Scheme: scheme,
+			}
+		})
+
+		It("should serve a pending certificate until it is issued", func() {
+			data, err := r.loadSSLCertificateValueData(ctx, proxy, certificate)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			inventory := certificateInventory{}
+			inventory.record("Instance", proxy.Name, certificate.Name, data)
+			Ω(inventory.pending()).Should(BeTrue())
+
+			certificates := inventory.certificates("Instance", proxy.Name)
+			Ω(certificates).Should(HaveLen(1))
+			Ω(certificates[0].DNSNames).Should(Equal([]string{"example.com"}))
+			reason, _ := certificateWarning(certificates[0], time.Now(), 30*24*time.Hour)
+			Ω(reason).Should(Equal("Pending"))
+		})
+
+		It("should keep the previous certificate until it is issued", func() {
+			cert, key, err := selfSignedCertificate([]string{"previous.example.com"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      utils.GetConfigSecretName(proxy),
+					Namespace: proxy.Namespace,
+				},
+				Data: map[string][]byte{
+					"example.crt": append(cert, key...),
+				},
+			}
+			Ω(r.Create(ctx, secret)).ShouldNot(HaveOccurred())
+
+			data, err := r.loadSSLCertificateValueData(ctx, proxy, certificate)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			inventory := certificateInventory{}
+			inventory.record("Instance", proxy.Name, certificate.Name, data)
+			Ω(inventory.certificates("Instance", proxy.Name)[0].DNSNames).Should(Equal([]string{"previous.example.com"}))
+		})
+
+		It("should detect expiring placeholders", func() {
+			cert, _, err := selfSignedCertificate([]string{"example.com"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(placeholderExpiring(cert, time.Now())).Should(BeFalse())
+			Ω(placeholderExpiring(cert, time.Now().Add(placeholderCertificateValidity-placeholderCertificateRenewBefore/2))).Should(BeTrue())
+			Ω(placeholderExpiring([]byte("Certificate"), time.Now())).Should(BeFalse())
+		})
+	})
+})
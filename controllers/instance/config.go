@@ -24,15 +24,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-func (r *Reconciler) reconcileConfig(ctx context.Context, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList, resolvers *configv1alpha1.ResolverList) (string, error) {
+func (r *Reconciler) reconcileConfig(ctx context.Context, instance *proxyv1alpha1.Instance, inventory certificateInventory, ticketKeys string, defaults *configv1alpha1.DefaultsList, crtStores *configv1alpha1.CrtStoreList, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList, resolvers *configv1alpha1.ResolverList, discovered map[string][]configv1alpha1.Server) (string, error) {
 	logger := log.FromContext(ctx)
 
-	config, err := r.generateHAPProxyConfiguration(ctx, instance, listens, frontends, backends, resolvers)
+	config, err := r.generateHAPProxyConfiguration(ctx, instance, defaults, crtStores, listens, frontends, backends, resolvers, discovered)
 	if err != nil {
 		return "", err
 	}
 
-	certificates, err := r.generateCertificates(ctx, instance, listens, frontends, backends)
+	certificates, err := r.generateCertificates(ctx, instance, inventory, crtStores, listens, frontends, backends)
 	if err != nil {
 		return "", err
 	}
@@ -47,18 +47,20 @@ func (r *Reconciler) reconcileConfig(ctx context.Context, instance *proxyv1alpha
 		return "", err
 	}
 
-	errorFiles, err := r.generateErrorFiles(ctx, instance, frontends, backends)
+	errorFiles, err := r.generateErrorFiles(ctx, instance, defaults, frontends, backends)
 	if err != nil {
 		return "", err
 	}
 
-	customCerts, err := r.generateCustomCertificatesFile(ctx, instance, frontends, listens)
+	customCerts, err := r.generateCustomCertificatesFile(ctx, instance, inventory, frontends, listens)
 	if err != nil {
 		return "", err
 	}
 
 	aclValueFiles := r.generateACLValuesFiles(ctx, listens, frontends, backends)
 
+	tracingFiles := generateTracingFiles(instance)
+
 	configSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      utils.GetConfigSecretName(instance),
@@ -82,6 +84,10 @@ func (r *Reconciler) reconcileConfig(ctx context.Context, instance *proxyv1alpha
 			configSecret.Data[filepath.Base(file)] = []byte(certificate)
 		}
 
+		if ticketKeys != "" {
+			configSecret.Data[filepath.Base(configv1alpha1.TLSTicketKeysFilePath)] = []byte(ticketKeys)
+		}
+
 		if len(envs) > 0 {
 			configSecret.Data["env"] = []byte(strings.Join(envs, "/n"))
 		}
@@ -102,6 +108,10 @@ func (r *Reconciler) reconcileConfig(ctx context.Context, instance *proxyv1alpha
 			configSecret.Data[filepath.Base(file)] = []byte(data)
 		}
 
+		for file, data := range tracingFiles {
+			configSecret.Data[filepath.Base(file)] = []byte(data)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -113,6 +123,29 @@ func (r *Reconciler) reconcileConfig(ctx context.Context, instance *proxyv1alpha
 
 	cs := generateChecksum(configSecret)
 
+	if operatorRuntimeAPI(instance) != nil {
+		secret := configSecret.DeepCopy()
+
+		if len(discovered) > 0 {
+			// discovered servers are updated using the runtime API and must not trigger a rollout
+			structural, err := r.generateHAPProxyConfiguration(ctx, instance, defaults, crtStores, listens, frontends, backends, resolvers, nil)
+			if err != nil {
+				return "", err
+			}
+			secret.Data[filepath.Base(haproxy.DefaultConfigurationFile)] = []byte(structural)
+		}
+
+		// certificates and crt-list entries are updated using the runtime API and must not trigger a rollout
+		for _, file := range newRuntimeCertificates(secret, listens, frontends, backends).files() {
+			delete(secret.Data, filepath.Base(file))
+		}
+
+		// rotated TLS ticket keys are added using the runtime API and must not trigger a rollout
+		delete(secret.Data, filepath.Base(configv1alpha1.TLSTicketKeysFilePath))
+
+		cs = generateChecksum(secret)
+	}
+
 	return cs, nil
 }
 
@@ -132,7 +165,7 @@ func generateChecksum(secret *corev1.Secret) string {
 	return hex.EncodeToString(hash[:])
 }
 
-func (r *Reconciler) generateHAPProxyConfiguration(ctx context.Context, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList, resolvers *configv1alpha1.ResolverList) (string, error) {
+func (r *Reconciler) generateHAPProxyConfiguration(ctx context.Context, instance *proxyv1alpha1.Instance, defaults *configv1alpha1.DefaultsList, crtStores *configv1alpha1.CrtStoreList, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList, resolvers *configv1alpha1.ResolverList, discovered map[string][]configv1alpha1.Server) (string, error) {
 	p, err := parser.New()
 	if err != nil {
 		return "", err
@@ -140,17 +173,61 @@ func (r *Reconciler) generateHAPProxyConfiguration(ctx context.Context, instance
 
 	nameKindMap := make(map[string]string)
 
+	// the health frontend is rendered by the instance, so configuration objects must not use its name
+	if instance.Spec.Health != nil && instance.Spec.Health.Enabled {
+		nameKindMap["health"] = "Instance"
+	}
+
 	if err := instance.AddToParser(p); err != nil {
 		return "", err
 	}
 
+	for i := range defaults.Items {
+		d := &defaults.Items[i]
+		d.GetObjectKind().SetGroupVersionKind(configv1alpha1.GroupVersion.WithKind("Defaults"))
+
+		if err = checkNameKind(nameKindMap, d); err == nil {
+			err = d.AddToParser(p)
+		}
+
+		if err != nil {
+			d.Status.Phase = configv1alpha1.StatusPhaseInternalError
+			d.Status.Error = err.Error()
+			return "", multierr.Combine(err, r.Status().Update(ctx, d))
+		}
+	}
+
+	for i := range crtStores.Items {
+		crtStore := &crtStores.Items[i]
+		crtStore.GetObjectKind().SetGroupVersionKind(configv1alpha1.GroupVersion.WithKind("CrtStore"))
+
+		if err = checkNameKind(nameKindMap, crtStore); err == nil {
+			err = crtStore.AddToParser(p)
+		}
+
+		if err != nil {
+			crtStore.Status.Phase = configv1alpha1.StatusPhaseInternalError
+			crtStore.Status.Error = err.Error()
+			return "", multierr.Combine(err, r.Status().Update(ctx, crtStore))
+		}
+	}
+
 	for i := range listens.Items {
 		listen := &listens.Items[i]
 		listen.GetObjectKind().SetGroupVersionKind(configv1alpha1.GroupVersion.WithKind("Listen"))
 
 		if err = checkNameKind(nameKindMap, listen); err == nil {
+			err = checkDefaultsReference(nameKindMap, listen.Spec.BaseSpec)
+		}
+		if err == nil {
 			err = listen.AddToParser(p)
 		}
+		if err == nil && len(defaults.Items) > 0 && listen.Spec.Defaults == nil {
+			err = multierr.Combine(
+				p.SectionsDefaultsFromSet(parser.Frontends, listen.DeepCopy().ToFrontend().Name, parser.DefaultSectionName),
+				p.SectionsDefaultsFromSet(parser.Backends, listen.DeepCopy().ToBackend().Name, parser.DefaultSectionName),
+			)
+		}
 
 		if err != nil {
 			listen.Status.Phase = configv1alpha1.StatusPhaseInternalError
@@ -165,8 +242,14 @@ func (r *Reconciler) generateHAPProxyConfiguration(ctx context.Context, instance
 		frontend.GetObjectKind().SetGroupVersionKind(configv1alpha1.GroupVersion.WithKind("Frontend"))
 
 		if err = checkNameKind(nameKindMap, frontend); err == nil {
+			err = checkDefaultsReference(nameKindMap, frontend.Spec.BaseSpec)
+		}
+		if err == nil {
 			err = frontend.AddToParser(p)
 		}
+		if err == nil && len(defaults.Items) > 0 && frontend.Spec.Defaults == nil {
+			err = p.SectionsDefaultsFromSet(parser.Frontends, frontend.Name, parser.DefaultSectionName)
+		}
 
 		if err != nil {
 			frontend.Status.Phase = configv1alpha1.StatusPhaseInternalError
@@ -180,7 +263,13 @@ func (r *Reconciler) generateHAPProxyConfiguration(ctx context.Context, instance
 		backend.GetObjectKind().SetGroupVersionKind(configv1alpha1.GroupVersion.WithKind("Backend"))
 
 		if err = checkNameKind(nameKindMap, backend); err == nil {
-			err = backend.AddToParser(p)
+			err = checkDefaultsReference(nameKindMap, backend.Spec.BaseSpec)
+		}
+		if err == nil {
+			err = withDiscoveredServers(backend, discovered).AddToParser(p)
+		}
+		if err == nil && len(defaults.Items) > 0 && backend.Spec.Defaults == nil {
+			err = p.SectionsDefaultsFromSet(parser.Backends, backend.Name, parser.DefaultSectionName)
 		}
 
 		if err != nil {
@@ -209,6 +298,29 @@ func (r *Reconciler) generateHAPProxyConfiguration(ctx context.Context, instance
 		if err := instance.Spec.Metrics.AddToParser(p); err != nil {
 			return "", err
 		}
+		if instance.Spec.Metrics.Enabled && len(defaults.Items) > 0 {
+			if err := p.SectionsDefaultsFromSet(parser.Frontends, "metrics", parser.DefaultSectionName); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if instance.Spec.Health != nil {
+		if err := validateHealthProbes(instance); err != nil {
+			return "", err
+		}
+		if err := instance.Spec.Health.AddToParser(p); err != nil {
+			return "", err
+		}
+		if instance.Spec.Health.Enabled && len(defaults.Items) > 0 {
+			if err := p.SectionsDefaultsFromSet(parser.Frontends, "health", parser.DefaultSectionName); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := addTracingFilters(p, instance, listens, frontends, backends); err != nil {
+		return "", err
 	}
 
 	return p.String(), nil
@@ -316,10 +428,15 @@ func (r *Reconciler) generateBackendMappingFiles(ctx context.Context, instance *
 	return files, nil
 }
 
-func (r *Reconciler) generateErrorFiles(ctx context.Context, instance *proxyv1alpha1.Instance, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) (map[string]string, error) {
+func (r *Reconciler) generateErrorFiles(ctx context.Context, instance *proxyv1alpha1.Instance, defaults *configv1alpha1.DefaultsList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) (map[string]string, error) {
 	files := map[string]string{}
 
 	var list []configv1alpha1.StaticHTTPFile
+	for _, d := range defaults.Items {
+		for _, ef := range d.Spec.ErrorFiles {
+			list = append(list, ef.File)
+		}
+	}
 	for _, frontend := range frontends.Items {
 		for _, ef := range frontend.Spec.ErrorFiles {
 			list = append(list, ef.File)
@@ -387,6 +504,17 @@ func (r *Reconciler) generateACLValuesFiles(_ context.Context, listens *configv1
 	return files
 }
 
+// checkDefaultsReference verifies that a referenced defaults section is provided by a Defaults object of the instance.
+func checkDefaultsReference(nameKindMap map[string]string, spec configv1alpha1.BaseSpec) error {
+	if spec.Defaults == nil {
+		return nil
+	}
+	if kind := nameKindMap[spec.Defaults.Name]; kind != "Defaults" {
+		return fmt.Errorf("defaults %s not found", spec.Defaults.Name)
+	}
+	return nil
+}
+
 func checkNameKind(nameKindMap map[string]string, object client.Object) error {
 	if val, ok := nameKindMap[object.GetName()]; ok {
 		return fmt.Errorf("name %s already used by resource of kind %s", object.GetName(), val)
@@ -3,18 +3,26 @@ package instance
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
 	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/certs"
 	"go.uber.org/multierr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-func (r *Reconciler) generateCertificates(ctx context.Context, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) (map[string]string, error) {
+func (r *Reconciler) generateCertificates(ctx context.Context, instance *proxyv1alpha1.Instance, inventory certificateInventory, crtStores *configv1alpha1.CrtStoreList, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) (map[string]string, error) {
 	certificates := map[string]string{}
 
 	for idx := range instance.Spec.Configuration.Global.AdditionalCertificates {
@@ -28,6 +36,34 @@ func (r *Reconciler) generateCertificates(ctx context.Context, instance *proxyv1
 		}
 
 		certificates[certificate.FilePath()] = data
+		inventory.record("Instance", instance.Name, certificate.Name, data)
+	}
+
+	for _, provider := range instance.Spec.Configuration.Global.ACME {
+		data, err := r.loadACMEAccountKey(ctx, instance, provider)
+		if err != nil {
+			instance.Status.Phase = proxyv1alpha1.InstancePhaseInternalError
+			instance.Status.Error = err.Error()
+			return certificates, multierr.Combine(err, r.Status().Update(ctx, instance))
+		}
+
+		certificates[provider.AccountKeyFilePath()] = data
+	}
+
+	for i := range crtStores.Items {
+		crtStore := crtStores.Items[i]
+
+		for _, certificate := range crtStore.Spec.Certificates {
+			files, err := r.loadCrtStoreCertificateData(ctx, instance, certificate)
+			if err != nil {
+				crtStore.Status.Phase = configv1alpha1.StatusPhaseInternalError
+				crtStore.Status.Error = err.Error()
+				return certificates, multierr.Combine(err, r.Status().Update(ctx, &crtStore))
+			}
+
+			maps.Copy(certificates, files)
+			inventory.record("CrtStore", crtStore.Name, certificate.Certificate.Name, files[certificate.Certificate.FilePath()])
+		}
 	}
 
 	for i := range listens.Items {
@@ -42,6 +78,7 @@ func (r *Reconciler) generateCertificates(ctx context.Context, instance *proxyv1
 			}
 
 			certificates[certificate.FilePath()] = data
+			inventory.record("Listen", listen.Name, certificate.Name, data)
 		}
 
 		for _, certificate := range extractSLCCertificatesFromBackend(listen.ToBackend()) {
@@ -53,6 +90,7 @@ func (r *Reconciler) generateCertificates(ctx context.Context, instance *proxyv1
 			}
 
 			certificates[certificate.FilePath()] = data
+			inventory.record("Listen", listen.Name, certificate.Name, data)
 		}
 	}
 
@@ -68,6 +106,7 @@ func (r *Reconciler) generateCertificates(ctx context.Context, instance *proxyv1
 			}
 
 			certificates[certificate.FilePath()] = data
+			inventory.record("Frontend", frontend.Name, certificate.Name, data)
 		}
 	}
 
@@ -83,13 +122,14 @@ func (r *Reconciler) generateCertificates(ctx context.Context, instance *proxyv1
 			}
 
 			certificates[certificate.FilePath()] = data
+			inventory.record("Backend", backend.Name, certificate.Name, data)
 		}
 	}
 
 	return certificates, nil
 }
 
-func (r *Reconciler) generateCustomCertificatesFile(ctx context.Context, instance *proxyv1alpha1.Instance, frontends *configv1alpha1.FrontendList, listens *configv1alpha1.ListenList) (map[string]string, error) {
+func (r *Reconciler) generateCustomCertificatesFile(ctx context.Context, instance *proxyv1alpha1.Instance, inventory certificateInventory, frontends *configv1alpha1.FrontendList, listens *configv1alpha1.ListenList) (map[string]string, error) {
 	files := map[string]string{}
 	var mappings []string
 
@@ -125,30 +165,27 @@ func (r *Reconciler) generateCustomCertificatesFile(ctx context.Context, instanc
 					}
 				}
 
+				if bind.SSLCertificateList.SecretSelector != nil {
+					selected, err := r.loadSecretCertificateListElements(ctx, instance, bind.SSLCertificateList.SecretSelector)
+					if err != nil {
+						frontend.Status.Phase = configv1alpha1.StatusPhaseInternalError
+						frontend.Status.Error = err.Error()
+						return files, multierr.Combine(err, r.Status().Update(ctx, &frontend))
+					}
+					elements = append(elements, selected...)
+				}
+
 				for _, element := range elements {
-					data, err := r.loadSSLCertificateValueData(ctx, instance, &element.Certificate)
+					data, err := r.loadCertificateListElementData(ctx, instance, element)
 					if err != nil {
 						frontend.Status.Phase = configv1alpha1.StatusPhaseInternalError
 						frontend.Status.Error = err.Error()
 						return nil, multierr.Combine(err, r.Status().Update(ctx, &frontend))
 					}
 					files[element.Certificate.FilePath()] = data
+					inventory.record("Frontend", frontend.Name, element.Certificate.Name, data)
 
-					var alpn string
-					if len(element.Alpn) > 0 {
-						if element.Ocsp {
-							if element.OcspFile != nil {
-								files[element.OcspFile.FilePath()] = *element.OcspFile.Value
-								alpn = fmt.Sprintf("[alpn %s %s %s %s]", strings.Join(element.Alpn, ","), "ocsp-update on", "ocsp", element.OcspFile.FilePath())
-							} else {
-								alpn = fmt.Sprintf("[alpn %s %s]", strings.Join(element.Alpn, ","), "ocsp-update on")
-							}
-						} else {
-							alpn = fmt.Sprintf("[alpn %s]", strings.Join(element.Alpn, ","))
-						}
-					}
-
-					mappings = append(mappings, strings.Join([]string{element.Certificate.FilePath(), alpn, element.SNIFilter, "\n"}, " "))
+					mappings = append(mappings, crtListEntry(element, files))
 				}
 
 				sort.Strings(mappings)
@@ -171,30 +208,27 @@ func (r *Reconciler) generateCustomCertificatesFile(ctx context.Context, instanc
 					elements = append(elements, *listen.Spec.HostCertificate)
 				}
 
+				if bind.SSLCertificateList.SecretSelector != nil {
+					selected, err := r.loadSecretCertificateListElements(ctx, instance, bind.SSLCertificateList.SecretSelector)
+					if err != nil {
+						listen.Status.Phase = configv1alpha1.StatusPhaseInternalError
+						listen.Status.Error = err.Error()
+						return files, multierr.Combine(err, r.Status().Update(ctx, &listen))
+					}
+					elements = append(elements, selected...)
+				}
+
 				for _, element := range elements {
-					data, err := r.loadSSLCertificateValueData(ctx, instance, &element.Certificate)
+					data, err := r.loadCertificateListElementData(ctx, instance, element)
 					if err != nil {
 						listen.Status.Phase = configv1alpha1.StatusPhaseInternalError
 						listen.Status.Error = err.Error()
 						return nil, multierr.Combine(err, r.Status().Update(ctx, &listen))
 					}
 					files[element.Certificate.FilePath()] = data
+					inventory.record("Listen", listen.Name, element.Certificate.Name, data)
 
-					var alpn string
-					if len(element.Alpn) > 0 {
-						if element.Ocsp {
-							if element.OcspFile != nil {
-								files[element.OcspFile.FilePath()] = *element.OcspFile.Value
-								alpn = fmt.Sprintf("[alpn %s %s %s %s]", strings.Join(element.Alpn, ","), "ocsp-update on", "ocsp", element.OcspFile.FilePath())
-							} else {
-								alpn = fmt.Sprintf("[alpn %s %s]", strings.Join(element.Alpn, ","), "ocsp-update on")
-							}
-						} else {
-							alpn = fmt.Sprintf("[alpn %s]", strings.Join(element.Alpn, ","))
-						}
-					}
-
-					mappings = append(mappings, strings.Join([]string{element.Certificate.FilePath(), alpn, element.SNIFilter, "\n"}, " "))
+					mappings = append(mappings, crtListEntry(element, files))
 				}
 
 				sort.Strings(mappings)
@@ -206,11 +240,229 @@ func (r *Reconciler) generateCustomCertificatesFile(ctx context.Context, instanc
 	return files, nil
 }
 
+// crtListEntry returns the crt-list line of an element and adds its OCSP response to the files.
+func crtListEntry(element configv1alpha1.CertificateListElement, files map[string]string) string {
+	var sslOptions []string
+	if len(element.Alpn) > 0 {
+		sslOptions = append(sslOptions, "alpn "+strings.Join(element.Alpn, ","))
+		if element.Ocsp {
+			sslOptions = append(sslOptions, "ocsp-update on")
+			if element.OcspFile != nil {
+				files[element.OcspFile.FilePath()] = *element.OcspFile.Value
+				sslOptions = append(sslOptions, "ocsp "+element.OcspFile.FilePath())
+			}
+		}
+	}
+
+	if element.ACME != nil {
+		sslOptions = append(sslOptions, element.ACME.String())
+	}
+
+	var options string
+	if len(sslOptions) > 0 {
+		options = fmt.Sprintf("[%s]", strings.Join(sslOptions, " "))
+	}
+
+	return strings.Join([]string{element.Certificate.FilePath(), options, element.SNIFilter, "\n"}, " ")
+}
+
+// loadCertificateListElementData loads the certificate of a crt-list element, certificates issued by ACME are
+// loaded from the Secret they are persisted in.
+func (r *Reconciler) loadCertificateListElementData(ctx context.Context, instance *proxyv1alpha1.Instance, element configv1alpha1.CertificateListElement) (string, error) {
+	if element.ACME != nil {
+		return r.loadACMECertificateData(ctx, instance, element)
+	}
+
+	return r.loadSSLCertificateValueData(ctx, instance, &element.Certificate)
+}
+
+// loadSecretCertificateListElements returns a crt-list element for every kubernetes.io/tls Secret selected by the
+// selector. The SNI filters are derived from the DNS names of the certificates, or the subject common name of
+// certificates without DNS names. Secrets without any name are skipped, as an empty SNI filter matches every request.
+func (r *Reconciler) loadSecretCertificateListElements(ctx context.Context, instance *proxyv1alpha1.Instance, selector *configv1alpha1.CertificateSecretSelector) ([]configv1alpha1.CertificateListElement, error) {
+	logger := log.FromContext(ctx)
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := selector.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{instance.Namespace}
+	}
+
+	var elements []configv1alpha1.CertificateListElement
+	for _, namespace := range namespaces {
+		secrets := &corev1.SecretList{}
+		if err := r.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+			return nil, err
+		}
+
+		for _, secret := range secrets.Items {
+			if secret.Type != corev1.SecretTypeTLS {
+				continue
+			}
+			if err := r.checkSecretReference(ctx, instance, secret.Namespace, secret.Name); err != nil {
+				return nil, err
+			}
+
+			certificates, err := certs.Certificates(secret.Data[corev1.TLSCertKey])
+			if err == nil && len(certificates) == 0 {
+				err = fmt.Errorf("no certificate found")
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate in secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+
+			names := certificates[0].DNSNames
+			if len(names) == 0 && certificates[0].Subject.CommonName != "" {
+				names = []string{certificates[0].Subject.CommonName}
+			}
+			if len(names) == 0 {
+				logger.Info("Skipping certificate without DNS names", "secret", secret.Namespace+"/"+secret.Name)
+				continue
+			}
+
+			data := strings.Join([]string{strings.TrimSpace(string(secret.Data[corev1.TLSCertKey])), strings.TrimSpace(string(secret.Data[corev1.TLSPrivateKeyKey]))}, "\n")
+			elements = append(elements, configv1alpha1.CertificateListElement{
+				Certificate: configv1alpha1.SSLCertificate{Name: secret.Namespace + "_" + secret.Name, Value: &data},
+				SNIFilter:   strings.Join(names, " "),
+				Alpn:        selector.Alpn,
+			})
+		}
+	}
+
+	return elements, nil
+}
+
+// secretToInstances maps a kubernetes.io/tls Secret to the instances owning frontends or listens with a bind
+// selecting it. Secrets in other namespaces are only mapped if a ReferenceGrant allows the reference.
+func (r *Reconciler) secretToInstances(ctx context.Context, object client.Object) []reconcile.Request {
+	secret, ok := object.(*corev1.Secret)
+	if !ok || secret.Type != corev1.SecretTypeTLS {
+		return nil
+	}
+
+	logger := log.FromContext(ctx)
+
+	frontends := &configv1alpha1.FrontendList{}
+	if err := r.List(ctx, frontends); err != nil {
+		logger.Error(err, "Unable to list frontends")
+		return nil
+	}
+	listens := &configv1alpha1.ListenList{}
+	if err := r.List(ctx, listens); err != nil {
+		logger.Error(err, "Unable to list listens")
+		return nil
+	}
+
+	objects := map[client.Object][]configv1alpha1.Bind{}
+	for i := range frontends.Items {
+		objects[&frontends.Items[i]] = frontends.Items[i].Spec.Binds
+	}
+	for i := range listens.Items {
+		objects[&listens.Items[i]] = listens.Items[i].Spec.Binds
+	}
+
+	var requests []reconcile.Request
+	for object, binds := range objects {
+		owner := metav1.GetControllerOf(object)
+		if owner == nil || owner.Kind != "Instance" {
+			continue
+		}
+
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: owner.Name, Namespace: object.GetNamespace()}}
+		if slices.Contains(requests, request) || !slices.ContainsFunc(binds, func(bind configv1alpha1.Bind) bool {
+			return secretSelected(bind, object.GetNamespace(), secret)
+		}) {
+			continue
+		}
+
+		instance := &proxyv1alpha1.Instance{ObjectMeta: metav1.ObjectMeta{Name: owner.Name, Namespace: object.GetNamespace()}}
+		if err := r.checkSecretReference(ctx, instance, secret.Namespace, secret.Name); err != nil {
+			continue
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests
+}
+
+// secretSelected returns whether the secret selector of the bind in the namespace selects the Secret.
+func secretSelected(bind configv1alpha1.Bind, namespace string, secret *corev1.Secret) bool {
+	if bind.SSLCertificateList == nil || bind.SSLCertificateList.SecretSelector == nil {
+		return false
+	}
+	selector := bind.SSLCertificateList.SecretSelector
+
+	namespaces := selector.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{namespace}
+	}
+	if !slices.Contains(namespaces, secret.Namespace) {
+		return false
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.LabelSelector)
+	return err == nil && labelSelector.Matches(labels.Set(secret.Labels))
+}
+
+// loadCrtStoreCertificateData loads the certificate, the private key and the OCSP response of a crt-store certificate
+// into separate files, as crt-store sections load them individually.
+func (r *Reconciler) loadCrtStoreCertificateData(ctx context.Context, instance *proxyv1alpha1.Instance, certificate configv1alpha1.CrtStoreCertificate) (map[string]string, error) {
+	files := map[string]string{}
+
+	data, err := r.loadSSLCertificateValueData(ctx, instance, &certificate.Certificate)
+	if err != nil {
+		return files, err
+	}
+	files[certificate.Certificate.FilePath()] = data
+
+	if certificate.Key != nil {
+		key, err := r.loadSSLCertificateSourceData(ctx, instance, certificate.Key)
+		if err != nil {
+			return files, err
+		}
+		if err := certs.ValidateKey([]byte(key), []byte(data)); err != nil {
+			return files, fmt.Errorf("invalid key %s: %w", certificate.Key.Name, err)
+		}
+		files[certificate.KeyFilePath()] = key
+	}
+
+	if certificate.OcspFile != nil && certificate.OcspFile.Value != nil {
+		files[certificate.OcspFile.FilePath()] = *certificate.OcspFile.Value
+	}
+
+	return files, nil
+}
+
+// loadSSLCertificateValueData loads the data of a certificate and validates the PEM material before it is written
+// into the configuration Secret.
 func (r *Reconciler) loadSSLCertificateValueData(ctx context.Context, instance *proxyv1alpha1.Instance, certificate *configv1alpha1.SSLCertificate) (string, error) {
+	data, err := r.loadSSLCertificateSourceData(ctx, instance, certificate)
+	if err != nil {
+		return "", err
+	}
+
+	validated, err := certs.Validate([]byte(data), time.Now())
+	if err != nil {
+		return "", fmt.Errorf("invalid certificate %s: %w", certificate.Name, err)
+	}
+
+	return string(validated), nil
+}
+
+func (r *Reconciler) loadSSLCertificateSourceData(ctx context.Context, instance *proxyv1alpha1.Instance, certificate *configv1alpha1.SSLCertificate) (string, error) {
 	if certificate.Value != nil {
 		return *certificate.Value, nil
 	}
 
+	if certificate.CertManager != nil {
+		return r.loadCertManagerCertificateData(ctx, instance, certificate)
+	}
+
 	var items []string
 
 	for _, ref := range certificate.ValueFrom {
@@ -243,6 +495,10 @@ func (r *Reconciler) loadSSLCertificateValueData(ctx context.Context, instance *
 		}
 
 		if ref.SecretKeyExternalRef != nil {
+			if err := r.checkSecretReference(ctx, instance, ref.SecretKeyExternalRef.Namespace, ref.SecretKeyExternalRef.Name); err != nil {
+				return "", err
+			}
+
 			secret := &corev1.Secret{}
 			if err := r.Get(ctx, client.ObjectKey{Name: ref.SecretKeyExternalRef.Name, Namespace: ref.SecretKeyExternalRef.Namespace}, secret); err != nil {
 				return "", err
@@ -274,6 +530,12 @@ func extractSLCCertificatesFromFrontend(frontend *configv1alpha1.Frontend) []*co
 		if bind.SSL.CACertificate != nil {
 			certificates = append(certificates, bind.SSL.CACertificate)
 		}
+		if bind.SSL.CRLFile != nil {
+			certificates = append(certificates, bind.SSL.CRLFile)
+		}
+		if bind.SSL.CAVerifyFile != nil {
+			certificates = append(certificates, bind.SSL.CAVerifyFile)
+		}
 	}
 
 	return certificates
@@ -293,6 +555,9 @@ func extractSLCCertificatesFromBackend(backend *configv1alpha1.Backend) []*confi
 		if server.SSL.CACertificate != nil {
 			certificates = append(certificates, server.SSL.CACertificate)
 		}
+		if server.SSL.CRLFile != nil {
+			certificates = append(certificates, server.SSL.CRLFile)
+		}
 	}
 
 	return certificates
@@ -0,0 +1,148 @@
+package instance
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("ConfigCerts", Label("controller"), func() {
+	Context("secretToInstances", func() {
+		var (
+			ctx      context.Context
+			r        *Reconciler
+			proxy    *proxyv1alpha1.Instance
+			frontend *configv1alpha1.Frontend
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Ω(clientgoscheme.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+			Ω(configv1alpha1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+			Ω(proxyv1alpha1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+
+			ctx = context.Background()
+
+			labels := map[string]string{"label-test": "ok"}
+
+			proxy = &proxyv1alpha1.Instance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar-foo",
+					Namespace: "foo",
+					UID:       uuid.NewUUID(),
+				},
+				Spec: proxyv1alpha1.InstanceSpec{
+					Configuration: proxyv1alpha1.Configuration{
+						LabelSelector: metav1.LabelSelector{MatchLabels: labels},
+					},
+				},
+			}
+			frontend = &configv1alpha1.Frontend{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "secrets",
+					Namespace: "foo",
+					Labels:    labels,
+				},
+				Spec: configv1alpha1.FrontendSpec{
+					Binds: []configv1alpha1.Bind{
+						{
+							Address: "unix@/var/lib/haproxy/run/local.sock",
+							Port:    9443,
+							Name:    "https",
+							Hidden:  ptr.To(true),
+							SSL: &configv1alpha1.SSL{
+								Enabled: true,
+							},
+							SSLCertificateList: &configv1alpha1.CertificateList{
+								Name: "secret_list",
+								SecretSelector: &configv1alpha1.CertificateSecretSelector{
+									LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tls": "web"}},
+									Namespaces:    []string{"foo", "other-ns"},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			r = &Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(proxy, frontend).WithStatusSubresource(proxy, frontend).Build(),
+				Scheme: scheme,
+			}
+		})
+
+		tlsSecret := func(namespace string, labels map[string]string) *corev1.Secret {
+			cert, key, err := selfSignedCertificate([]string{"web.example.com"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace, Labels: labels},
+				Type:       corev1.SecretTypeTLS,
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       cert,
+					corev1.TLSPrivateKeyKey: key,
+				},
+			}
+		}
+
+		It("should update the crt-list when a selected secret is created", func() {
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: proxy.Name, Namespace: proxy.Namespace}}
+			_, err := r.Reconcile(ctx, ctrl.Request(request))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			config := &corev1.Secret{}
+			Ω(r.Get(ctx, client.ObjectKey{Namespace: proxy.Namespace, Name: utils.GetConfigSecretName(proxy)}, config)).ShouldNot(HaveOccurred())
+			Ω(string(config.Data["secret_list.map"])).Should(BeEmpty())
+
+			secret := tlsSecret(proxy.Namespace, map[string]string{"tls": "web"})
+			Ω(r.Create(ctx, secret)).ShouldNot(HaveOccurred())
+			Ω(r.secretToInstances(ctx, secret)).Should(Equal([]reconcile.Request{request}))
+
+			_, err = r.Reconcile(ctx, ctrl.Request(request))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(r.Get(ctx, client.ObjectKeyFromObject(config), config)).ShouldNot(HaveOccurred())
+			Ω(string(config.Data["secret_list.map"])).Should(Equal("/usr/local/etc/haproxy/foo_web.crt  web.example.com \n"))
+		})
+
+		It("should not map secrets which are not selected", func() {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: proxy.Name, Namespace: proxy.Namespace}})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(r.secretToInstances(ctx, tlsSecret(proxy.Namespace, map[string]string{"tls": "api"}))).Should(BeEmpty())
+			Ω(r.secretToInstances(ctx, tlsSecret("bar", map[string]string{"tls": "web"}))).Should(BeEmpty())
+		})
+
+		It("should map secrets of other namespaces only with a ReferenceGrant", func() {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: proxy.Name, Namespace: proxy.Namespace}})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			secret := tlsSecret("other-ns", map[string]string{"tls": "web"})
+			Ω(r.secretToInstances(ctx, secret)).Should(BeEmpty())
+
+			Ω(r.Create(ctx, &configv1alpha1.ReferenceGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "allow-foo", Namespace: "other-ns"},
+				Spec: configv1alpha1.ReferenceGrantSpec{
+					From: []configv1alpha1.ReferenceGrantFrom{{Namespace: "foo"}},
+					To:   []configv1alpha1.ReferenceGrantTo{{Name: "web"}},
+				},
+			})).ShouldNot(HaveOccurred())
+			Ω(r.secretToInstances(ctx, secret)).Should(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,308 @@
+package instance
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	parser "github.com/haproxytech/client-native/v6/config-parser"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/runtimeapi"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// discoverBackendServers returns the servers discovered from the EndpointSlices of the Services referenced by
+// the backends, keyed by backend name.
+func (r *Reconciler) discoverBackendServers(ctx context.Context, backends *configv1alpha1.BackendList) (map[string][]configv1alpha1.Server, error) {
+	discovered := map[string][]configv1alpha1.Server{}
+
+	for i := range backends.Items {
+		backend := &backends.Items[i]
+		ref := backend.Spec.ServiceRef
+		if ref == nil {
+			continue
+		}
+
+		endpointSlices := &discoveryv1.EndpointSliceList{}
+		err := r.List(ctx, endpointSlices, client.InNamespace(utils.StringOrDefault(ref.Namespace, backend.Namespace)), client.MatchingLabels{discoveryv1.LabelServiceName: ref.Name})
+		if err == nil {
+			discovered[backend.Name], err = ref.Servers(endpointSlices.Items)
+		}
+
+		if err != nil {
+			backend.Status.Phase = configv1alpha1.StatusPhaseInternalError
+			backend.Status.Error = err.Error()
+			return nil, multierr.Combine(err, r.Status().Update(ctx, backend))
+		}
+	}
+
+	return discovered, nil
+}
+
+// withDiscoveredServers returns a copy of the backend including the discovered servers.
+func withDiscoveredServers(backend *configv1alpha1.Backend, discovered map[string][]configv1alpha1.Server) *configv1alpha1.Backend {
+	servers, ok := discovered[backend.Name]
+	if !ok {
+		return backend
+	}
+
+	b := backend.DeepCopy()
+	b.Spec.Servers = append(b.Spec.Servers, servers...)
+	return b
+}
+
+// backendServiceRefIndex is the field index of the backends by the namespace and name of their referenced Service.
+const backendServiceRefIndex = "spec.serviceRef"
+
+// backendServiceRef returns the namespace and name of the Service referenced by a backend for the field index.
+func backendServiceRef(object client.Object) []string {
+	backend, ok := object.(*configv1alpha1.Backend)
+	if !ok || backend.Spec.ServiceRef == nil {
+		return nil
+	}
+
+	ref := backend.Spec.ServiceRef
+	return []string{types.NamespacedName{Namespace: utils.StringOrDefault(ref.Namespace, backend.Namespace), Name: ref.Name}.String()}
+}
+
+// endpointSliceToInstances maps an EndpointSlice to the instances owning backends which reference its Service.
+func (r *Reconciler) endpointSliceToInstances(ctx context.Context, object client.Object) []reconcile.Request {
+	service := object.GetLabels()[discoveryv1.LabelServiceName]
+	if service == "" {
+		return nil
+	}
+
+	backends := &configv1alpha1.BackendList{}
+	ref := types.NamespacedName{Namespace: object.GetNamespace(), Name: service}
+	if err := r.List(ctx, backends, client.MatchingFields{backendServiceRefIndex: ref.String()}); err != nil {
+		log.FromContext(ctx).Error(err, "Unable to list backends")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range backends.Items {
+		backend := &backends.Items[i]
+		if owner := metav1.GetControllerOf(backend); owner != nil && owner.Kind == "Instance" {
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: owner.Name, Namespace: backend.Namespace}}
+			if !slices.Contains(requests, request) {
+				requests = append(requests, request)
+			}
+		}
+	}
+
+	return requests
+}
+
+// reconcileRuntimeServers pushes the servers of backends with a service reference to the running pods using the
+// Runtime API. Failures are only logged, the pods pick up the servers with the next configuration reload.
+func (r *Reconciler) reconcileRuntimeServers(ctx context.Context, instance *proxyv1alpha1.Instance, backends *configv1alpha1.BackendList, discovered map[string][]configv1alpha1.Server) {
+	logger := log.FromContext(ctx)
+
+	runtimeAPI := operatorRuntimeAPI(instance)
+	if runtimeAPI == nil || len(discovered) == 0 {
+		return
+	}
+
+	desired, err := renderedServers(backends, discovered)
+	if err != nil {
+		logger.Error(err, "Unable to render discovered servers")
+		return
+	}
+	declared, err := declaredServers(backends, discovered)
+	if err != nil {
+		logger.Error(err, "Unable to render declared servers")
+		return
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(instance.Namespace), client.MatchingLabels(utils.GetAppSelectorLabels(instance))); err != nil {
+		logger.Error(err, "Unable to list pods")
+		return
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+
+		c := runtimeapi.NewClient(net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(runtimeAPI.Port))))
+		for backend, servers := range desired {
+			if err := syncRuntimeServers(c, backend, servers, declared[backend]); err != nil {
+				logger.Error(err, "Unable to update servers using the runtime API", "pod", pod.Name, "backend", backend)
+			}
+		}
+	}
+}
+
+// renderedServers renders the backends with a service reference and returns the arguments of their server lines
+// keyed by backend and server name.
+func renderedServers(backends *configv1alpha1.BackendList, discovered map[string][]configv1alpha1.Server) (map[string]map[string]string, error) {
+	rendered := map[string]map[string]string{}
+
+	for i := range backends.Items {
+		backend := &backends.Items[i]
+		if _, ok := discovered[backend.Name]; !ok {
+			continue
+		}
+
+		p, err := parser.New()
+		if err != nil {
+			return nil, err
+		}
+		if err := withDiscoveredServers(backend, discovered).AddToParser(p); err != nil {
+			return nil, err
+		}
+
+		servers := map[string]string{}
+		for _, line := range strings.Split(p.String(), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 || fields[0] != "server" {
+				continue
+			}
+			servers[fields[1]] = strings.Join(fields[2:], " ")
+		}
+		rendered[backend.Name] = servers
+	}
+
+	return rendered, nil
+}
+
+// declaredServers renders the backends with a service reference without the discovered servers and returns the
+// names of their servers and server template slots keyed by backend. They are never removed at runtime.
+func declaredServers(backends *configv1alpha1.BackendList, discovered map[string][]configv1alpha1.Server) (map[string]map[string]bool, error) {
+	declared := map[string]map[string]bool{}
+
+	for i := range backends.Items {
+		backend := &backends.Items[i]
+		if _, ok := discovered[backend.Name]; !ok {
+			continue
+		}
+
+		p, err := parser.New()
+		if err != nil {
+			return nil, err
+		}
+		if err := backend.AddToParser(p); err != nil {
+			return nil, err
+		}
+
+		names := map[string]bool{}
+		for _, line := range strings.Split(p.String(), "\n") {
+			fields := strings.Fields(line)
+			switch {
+			case len(fields) >= 3 && fields[0] == "server":
+				names[fields[1]] = true
+			case len(fields) >= 4 && fields[0] == "server-template":
+				slots, err := serverTemplateSlots(fields[1], fields[2])
+				if err != nil {
+					return nil, err
+				}
+				for _, name := range slots {
+					names[name] = true
+				}
+			}
+		}
+		declared[backend.Name] = names
+	}
+
+	return declared, nil
+}
+
+// serverTemplateSlots returns the server names of a server-template with the given prefix and number or range, e.g.
+// "1-3".
+func serverTemplateSlots(prefix, numOrRange string) ([]string, error) {
+	first, last := "1", numOrRange
+	if before, after, ok := strings.Cut(numOrRange, "-"); ok {
+		first, last = before, after
+	}
+
+	from, err := strconv.Atoi(first)
+	if err != nil {
+		return nil, err
+	}
+	to, err := strconv.Atoi(last)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i := from; i <= to; i++ {
+		names = append(names, prefix+strconv.Itoa(i))
+	}
+
+	return names, nil
+}
+
+// syncRuntimeServers adds new servers, updates changed addresses and removes stale discovered servers. Servers
+// whose weight, ssl, check or backup arguments changed are replaced, as they cannot all be changed at runtime.
+// Declared servers and server template slots are never removed.
+func syncRuntimeServers(c *runtimeapi.Client, backend string, servers map[string]string, declared map[string]bool) error {
+	current, err := c.ServersState(backend)
+	if err != nil {
+		return err
+	}
+
+	add := func(name, args string) error {
+		if err := c.AddServer(backend, name, args); err != nil {
+			return err
+		}
+		return c.EnableServer(backend, name, slices.Contains(strings.Fields(args), "check"))
+	}
+
+	var errs error
+	for name, args := range servers {
+		fields := strings.Fields(args)
+		address, port, err := net.SplitHostPort(fields[0])
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		state, ok := current[name]
+		switch {
+		case !ok:
+			err = add(name, args)
+		case serverArgsChanged(state, fields) && !declared[name]:
+			err = c.DeleteServer(backend, name)
+			if err == nil {
+				err = add(name, args)
+			}
+		case state.Address != address || state.Port != port:
+			err = c.SetServerAddress(backend, name, address, port)
+		}
+		errs = multierr.Append(errs, err)
+	}
+
+	for name := range current {
+		if _, ok := servers[name]; !ok && !declared[name] {
+			errs = multierr.Append(errs, c.DeleteServer(backend, name))
+		}
+	}
+
+	return errs
+}
+
+// serverArgsChanged returns whether the weight, ssl, check or backup arguments of a server line differ from the
+// runtime state of the server. The weight is only compared if the state reports it.
+func serverArgsChanged(state runtimeapi.ServerState, fields []string) bool {
+	weight := "1"
+	if i := slices.Index(fields, "weight"); i >= 0 && i+1 < len(fields) {
+		weight = fields[i+1]
+	}
+
+	return (state.Weight != "" && state.Weight != weight) ||
+		state.SSL != slices.Contains(fields, "ssl") ||
+		state.Check != slices.Contains(fields, "check") ||
+		state.Backup != slices.Contains(fields, "backup")
+}
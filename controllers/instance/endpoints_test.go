@@ -0,0 +1,87 @@
+package instance
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Endpoints", Label("controller"), func() {
+	Context("endpointSliceToInstances", func() {
+		var (
+			ctx context.Context
+			r   *Reconciler
+		)
+
+		backend := func(name, instance string, ref *configv1alpha1.ServiceReference) *configv1alpha1.Backend {
+			return &configv1alpha1.Backend{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "foo",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: proxyv1alpha1.GroupVersion.String(),
+							Kind:       "Instance",
+							Name:       instance,
+							Controller: ptr.To(true),
+						},
+					},
+				},
+				Spec: configv1alpha1.BackendSpec{ServiceRef: ref},
+			}
+		}
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Ω(clientgoscheme.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+			Ω(configv1alpha1.AddToScheme(scheme)).ShouldNot(HaveOccurred())
+
+			ctx = context.Background()
+
+			objects := []client.Object{
+				backend("web", "bar-foo", &configv1alpha1.ServiceReference{Name: "web", Namespace: "web-ns"}),
+				backend("web-2", "bar-foo", &configv1alpha1.ServiceReference{Name: "web", Namespace: "web-ns"}),
+				backend("web-local", "baz-foo", &configv1alpha1.ServiceReference{Name: "web"}),
+				backend("static", "qux-foo", nil),
+			}
+
+			r = &Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithIndex(&configv1alpha1.Backend{}, backendServiceRefIndex, backendServiceRef).Build(),
+				Scheme: scheme,
+			}
+		})
+
+		It("should map endpoint slices to the instances of the backends referencing their service", func() {
+			endpointSlice := &discoveryv1.EndpointSlice{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web-abcde",
+					Namespace: "web-ns",
+					Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+				},
+			}
+			Ω(r.endpointSliceToInstances(ctx, endpointSlice)).Should(Equal([]reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "bar-foo", Namespace: "foo"}},
+			}))
+
+			endpointSlice.Namespace = "foo"
+			Ω(r.endpointSliceToInstances(ctx, endpointSlice)).Should(Equal([]reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "baz-foo", Namespace: "foo"}},
+			}))
+
+			endpointSlice.Labels[discoveryv1.LabelServiceName] = "api"
+			Ω(r.endpointSliceToInstances(ctx, endpointSlice)).Should(BeEmpty())
+		})
+	})
+})
@@ -6,12 +6,16 @@ import (
 	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
 	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
 	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -30,6 +34,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	instance := &proxyv1alpha1.Instance{}
 	if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
 		if errors.IsNotFound(err) {
+			deleteCertificateMetrics(req.Namespace, req.Name)
 			return reconcile.Result{}, nil
 		}
 
@@ -41,6 +46,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{}, err
 	}
 
+	defaults := &configv1alpha1.DefaultsList{}
+	if err := r.List(ctx, defaults, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	crtStores := &configv1alpha1.CrtStoreList{}
+	if err := r.List(ctx, crtStores, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	listens := &configv1alpha1.ListenList{}
 	if err := r.List(ctx, listens, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
 		return reconcile.Result{}, err
@@ -70,9 +85,24 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{}, r.Status().Update(ctx, instance)
 	}
 
+	discovered, err := r.discoverBackendServers(ctx, backends)
+	if err != nil {
+		return reconcile.Result{}, r.handleError(ctx, instance, err)
+	}
+
+	if err := r.checkTLSTicketKeys(ctx, instance, listens, frontends); err != nil {
+		return reconcile.Result{}, r.handleError(ctx, instance, err)
+	}
+
+	ticketKeys, ticketKeysRotation, err := r.reconcileTLSTicketKeys(ctx, instance)
+	if err != nil {
+		return reconcile.Result{}, r.handleError(ctx, instance, err)
+	}
+
 	var checksum string
 
-	if checksum, err = r.reconcileConfig(ctx, instance, listens, frontends, backends, resolvers); err != nil {
+	inventory := certificateInventory{}
+	if checksum, err = r.reconcileConfig(ctx, instance, inventory, ticketKeys, defaults, crtStores, listens, frontends, backends, resolvers, discovered); err != nil {
 		return reconcile.Result{}, r.handleError(ctx, instance, err)
 	}
 
@@ -102,16 +132,39 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{}, r.handleError(ctx, instance, err)
 	}
 
+	if err := r.reconcileRuntimeAPINetworkPolicy(ctx, instance); err != nil {
+		return reconcile.Result{}, r.handleError(ctx, instance, err)
+	}
+
+	r.reconcileRuntimeServers(ctx, instance, backends, discovered)
+	certificatesFailed := r.reconcileRuntimeCertificates(ctx, instance, listens, frontends, backends)
+	r.reconcileRuntimeTLSTicketKeys(ctx, instance, ticketKeys, listens, frontends)
+
+	acmePending := r.reconcileACMECertificates(ctx, instance, listens, frontends, backends)
+
+	conditions := instance.Status.Conditions
+	setCertificateCondition(&conditions, instance, inventory.certificates("Instance", instance.Name), instance.Generation)
+
 	instance.Status = proxyv1alpha1.InstanceStatus{
-		Phase: proxyv1alpha1.InstancePhaseRunning,
+		Phase:        proxyv1alpha1.InstancePhaseRunning,
+		Certificates: inventory.certificates("Instance", instance.Name),
+		Conditions:   conditions,
 	}
 	if err := r.Status().Update(ctx, instance); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	r.updateConfig(ctx, instance, listens, frontends, backends, resolvers)
+	r.updateConfig(ctx, instance, inventory, defaults, crtStores, listens, frontends, backends, resolvers)
+	inventory.updateMetrics(instance)
+
+	// the instance is reconciled again to check pending certificates, to retry failed certificate updates and to
+	// rotate the TLS ticket keys
+	result := ctrl.Result{RequeueAfter: ticketKeysRotation}
+	if (acmePending || certificatesFailed || inventory.pending()) && (result.RequeueAfter == 0 || certificateRequeueInterval < result.RequeueAfter) {
+		result.RequeueAfter = certificateRequeueInterval
+	}
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func (r *Reconciler) handleError(ctx context.Context, instance *proxyv1alpha1.Instance, err error) error {
@@ -123,29 +176,39 @@ func (r *Reconciler) handleError(ctx context.Context, instance *proxyv1alpha1.In
 	return multierr.Combine(err, r.Status().Update(ctx, instance))
 }
 
-func (r *Reconciler) updateConfig(ctx context.Context, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList, resolvers *configv1alpha1.ResolverList) {
+func (r *Reconciler) updateConfig(ctx context.Context, instance *proxyv1alpha1.Instance, inventory certificateInventory, defaults *configv1alpha1.DefaultsList, crtStores *configv1alpha1.CrtStoreList, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList, resolvers *configv1alpha1.ResolverList) {
+	for i := range defaults.Items {
+		d := defaults.Items[i]
+		_ = r.updateConfigObject(ctx, instance, inventory, &d)
+	}
+
+	for i := range crtStores.Items {
+		crtStore := crtStores.Items[i]
+		_ = r.updateConfigObject(ctx, instance, inventory, &crtStore)
+	}
+
 	for i := range listens.Items {
 		listen := listens.Items[i]
-		_ = r.updateConfigObject(ctx, instance, &listen)
+		_ = r.updateConfigObject(ctx, instance, inventory, &listen)
 	}
 
 	for i := range frontends.Items {
 		frontend := frontends.Items[i]
-		_ = r.updateConfigObject(ctx, instance, &frontend)
+		_ = r.updateConfigObject(ctx, instance, inventory, &frontend)
 	}
 
 	for i := range backends.Items {
 		backend := backends.Items[i]
-		_ = r.updateConfigObject(ctx, instance, &backend)
+		_ = r.updateConfigObject(ctx, instance, inventory, &backend)
 	}
 
 	for i := range resolvers.Items {
 		resolvers := resolvers.Items[i]
-		_ = r.updateConfigObject(ctx, instance, &resolvers)
+		_ = r.updateConfigObject(ctx, instance, inventory, &resolvers)
 	}
 }
 
-func (r *Reconciler) updateConfigObject(ctx context.Context, instance *proxyv1alpha1.Instance, object configv1alpha1.Object) error {
+func (r *Reconciler) updateConfigObject(ctx context.Context, instance *proxyv1alpha1.Instance, inventory certificateInventory, object configv1alpha1.Object) error {
 	logger := log.FromContext(ctx)
 
 	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, object, func() error {
@@ -156,9 +219,20 @@ func (r *Reconciler) updateConfigObject(ctx context.Context, instance *proxyv1al
 		return err
 	}
 
+	gvk, err := apiutil.GVKForObject(object, r.Scheme)
+	if err != nil {
+		return err
+	}
+	certificates := inventory.certificates(gvk.Kind, object.GetName())
+
+	conditions := object.GetStatus().Conditions
+	setCertificateCondition(&conditions, instance, certificates, object.GetGeneration())
+
 	object.SetStatus(configv1alpha1.Status{
 		Phase:              configv1alpha1.StatusPhaseActive,
 		ObservedGeneration: object.GetGeneration(),
+		Certificates:       certificates,
+		Conditions:         conditions,
 	})
 	if err := r.Status().Update(ctx, object); err != nil {
 		logger.Error(err, "Unable to update status", object.GetObjectKind().GroupVersionKind().Kind, object.GetName())
@@ -170,11 +244,26 @@ func (r *Reconciler) updateConfigObject(ctx context.Context, instance *proxyv1al
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &configv1alpha1.Backend{}, backendServiceRefIndex, backendServiceRef); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&proxyv1alpha1.Instance{}).
+		Owns(&configv1alpha1.Defaults{}).
+		Owns(&configv1alpha1.CrtStore{}).
 		Owns(&configv1alpha1.Listen{}).
 		Owns(&configv1alpha1.Frontend{}).
 		Owns(&configv1alpha1.Backend{}).
 		Owns(&configv1alpha1.Resolver{}).
-		Complete(r)
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(r.endpointSliceToInstances)).
+		Watches(&configv1alpha1.ReferenceGrant{}, handler.EnqueueRequestsFromMapFunc(r.referenceGrantToInstances)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.secretToInstances))
+
+	// cert-manager updates the status of a Certificate after renewing it, which triggers loading the new Secret.
+	if IsCertManagerAPIAvailable() {
+		b = b.Owns(newCertManagerCertificate())
+	}
+
+	return b.Complete(r)
 }
@@ -0,0 +1,57 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// checkSecretReference returns an error unless the Secret is in the namespace of the instance or a ReferenceGrant in
+// the namespace of the Secret allows the reference.
+func (r *Reconciler) checkSecretReference(ctx context.Context, instance *proxyv1alpha1.Instance, namespace, name string) error {
+	if namespace == instance.Namespace {
+		return nil
+	}
+
+	grants := &configv1alpha1.ReferenceGrantList{}
+	if err := r.List(ctx, grants, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+
+	for i := range grants.Items {
+		if grants.Items[i].Allows(instance.Namespace, name) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("reference to secret %s/%s denied: no ReferenceGrant in namespace %s allows namespace %s", namespace, name, namespace, instance.Namespace)
+}
+
+// referenceGrantToInstances maps a ReferenceGrant to the instances of the namespaces it allows.
+func (r *Reconciler) referenceGrantToInstances(ctx context.Context, object client.Object) []reconcile.Request {
+	grant, ok := object.(*configv1alpha1.ReferenceGrant)
+	if !ok {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, from := range grant.Spec.From {
+		instances := &proxyv1alpha1.InstanceList{}
+		if err := r.List(ctx, instances, client.InNamespace(from.Namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "Unable to list instances")
+			return nil
+		}
+
+		for _, instance := range instances.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}})
+		}
+	}
+
+	return requests
+}
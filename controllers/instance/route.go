@@ -41,7 +41,7 @@ func (r *Reconciler) createOrUpdateRouteForFrontend(ctx context.Context, instanc
 	logger := log.FromContext(ctx)
 
 	for _, bind := range frontend.Spec.Binds {
-		if ptr.Deref(bind.Hidden, false) {
+		if ptr.Deref(bind.Hidden, false) || bind.QUIC {
 			continue
 		}
 
@@ -0,0 +1,119 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// operatorRuntimeAPI returns the Runtime API of the instance if it is bound to a pod address, which the operator
+// can reach. Otherwise changes are rolled out to the pods.
+func operatorRuntimeAPI(instance *proxyv1alpha1.Instance) *proxyv1alpha1.RuntimeAPIConfiguration {
+	runtimeAPI := instance.Spec.Configuration.Global.RuntimeAPI
+	if runtimeAPI == nil || !runtimeAPI.Remote() {
+		return nil
+	}
+
+	return runtimeAPI
+}
+
+// reconcileRuntimeAPINetworkPolicy restricts the access to a Runtime API bound to a pod address to the operator pods.
+// All other ports stay reachable from everywhere, so they can still be restricted by additional NetworkPolicies.
+func (r *Reconciler) reconcileRuntimeAPINetworkPolicy(ctx context.Context, instance *proxyv1alpha1.Instance) error {
+	logger := log.FromContext(ctx)
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-haproxy-runtime-api", instance.Name),
+			Namespace: instance.Namespace,
+		},
+	}
+
+	runtimeAPI := operatorRuntimeAPI(instance)
+	if runtimeAPI == nil {
+		err := r.Get(ctx, client.ObjectKeyFromObject(policy), policy)
+		if err == nil {
+			if err := r.Delete(ctx, policy); err != nil {
+				return err
+			}
+			logger.Info("deleted", "networkpolicy", policy.Name)
+		}
+
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if err := controllerutil.SetOwnerReference(instance, policy, r.Scheme); err != nil {
+			return err
+		}
+		policy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: utils.GetAppSelectorLabels(instance)},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					Ports: portRangesExcept(runtimeAPI.Port),
+				},
+			},
+		}
+
+		// without the identity of the operator the Runtime API is not reachable at all
+		name, namespace := utils.GetOperatorName(), utils.GetOperatorNamespace()
+		if name != "" && namespace != "" {
+			policy.Spec.Ingress = append(policy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{corev1.LabelMetadataName: namespace}},
+						PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+					},
+				},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{
+						Protocol: ptr.To(corev1.ProtocolTCP),
+						Port:     ptr.To(intstr.FromInt32(runtimeAPI.Port)),
+					},
+				},
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if result != controllerutil.OperationResultNone {
+		logger.Info(fmt.Sprintf("Object %s", result), "networkpolicy", policy.Name)
+	}
+
+	return nil
+}
+
+// portRangesExcept returns the TCP and UDP port ranges covering all ports except the given TCP port.
+func portRangesExcept(port int32) []networkingv1.NetworkPolicyPort {
+	portRange := func(protocol corev1.Protocol, start, end int32) networkingv1.NetworkPolicyPort {
+		return networkingv1.NetworkPolicyPort{
+			Protocol: ptr.To(protocol),
+			Port:     ptr.To(intstr.FromInt32(start)),
+			EndPort:  ptr.To(end),
+		}
+	}
+
+	var ports []networkingv1.NetworkPolicyPort
+	if port > 1 {
+		ports = append(ports, portRange(corev1.ProtocolTCP, 1, port-1))
+	}
+	if port < 65535 {
+		ports = append(ports, portRange(corev1.ProtocolTCP, port+1, 65535))
+	}
+
+	return append(ports, portRange(corev1.ProtocolUDP, 1, 65535))
+}
@@ -0,0 +1,269 @@
+package instance
+
+import (
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/certs"
+	"github.com/six-group/haproxy-operator/pkg/runtimeapi"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// runtimeCertificatesTimeout bounds the time spent updating certificates of all pods, so unreachable pods do not
+// block the reconciliation.
+const runtimeCertificatesTimeout = 30 * time.Second
+
+// runtimeCertificates are the certificates and crt-lists of an instance which are updated using the Runtime API.
+type runtimeCertificates struct {
+	// certificates contains the PEM data keyed by certificate path.
+	certificates map[string]string
+	// crtLists contains the entries keyed by crt-list path.
+	crtLists map[string][]string
+}
+
+// newRuntimeCertificates collects the certificates of binds, servers and crt-lists from the configuration Secret.
+func newRuntimeCertificates(secret *corev1.Secret, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) runtimeCertificates {
+	c := runtimeCertificates{
+		certificates: map[string]string{},
+		crtLists:     map[string][]string{},
+	}
+
+	var binds []configv1alpha1.Bind
+	var servers []configv1alpha1.Server
+	for _, listen := range listens.Items {
+		binds = append(binds, listen.Spec.Binds...)
+		servers = append(servers, listen.Spec.Servers...)
+	}
+	for _, frontend := range frontends.Items {
+		binds = append(binds, frontend.Spec.Binds...)
+	}
+	for _, backend := range backends.Items {
+		servers = append(servers, backend.Spec.Servers...)
+	}
+
+	addCertificate := func(path string) {
+		if data, ok := secret.Data[filepath.Base(path)]; ok {
+			c.certificates[path] = string(data)
+		}
+	}
+
+	for _, bind := range binds {
+		if bind.SSL != nil && bind.SSL.Certificate != nil {
+			addCertificate(bind.SSL.Certificate.FilePath())
+		}
+
+		if bind.SSLCertificateList != nil {
+			var entries []string
+			for _, line := range strings.Split(string(secret.Data[filepath.Base(bind.SSLCertificateList.FilePath())]), "\n") {
+				fields := strings.Fields(line)
+				if len(fields) == 0 {
+					continue
+				}
+				addCertificate(fields[0])
+				entries = append(entries, strings.Join(fields, " "))
+			}
+			c.crtLists[bind.SSLCertificateList.FilePath()] = entries
+		}
+	}
+
+	for _, server := range servers {
+		if server.SSL != nil && server.SSL.Certificate != nil {
+			addCertificate(server.SSL.Certificate.FilePath())
+		}
+	}
+
+	return c
+}
+
+// files returns the paths of the certificates and crt-lists.
+func (c runtimeCertificates) files() []string {
+	var files []string
+	for path := range c.certificates {
+		files = append(files, path)
+	}
+	for path := range c.crtLists {
+		files = append(files, path)
+	}
+
+	return files
+}
+
+// reconcileRuntimeCertificates pushes changed certificates and crt-list entries to the running pods using the
+// Runtime API. Changed certificate files do not trigger a rollout, so it returns true if a pod could not be updated
+// and the update must be retried.
+func (r *Reconciler) reconcileRuntimeCertificates(ctx context.Context, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) bool {
+	logger := log.FromContext(ctx)
+
+	runtimeAPI := operatorRuntimeAPI(instance)
+	if runtimeAPI == nil {
+		return false
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: utils.GetConfigSecretName(instance), Namespace: instance.Namespace}, secret); err != nil {
+		logger.Error(err, "Unable to get configuration secret")
+		return true
+	}
+
+	desired := newRuntimeCertificates(secret, listens, frontends, backends)
+	if len(desired.certificates) == 0 && len(desired.crtLists) == 0 {
+		return false
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(instance.Namespace), client.MatchingLabels(utils.GetAppSelectorLabels(instance))); err != nil {
+		logger.Error(err, "Unable to list pods")
+		return true
+	}
+
+	var failed bool
+	deadline := time.Now().Add(runtimeCertificatesTimeout)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+		if time.Now().After(deadline) {
+			logger.Info("Timeout while updating certificates using the runtime API, retrying later", "pod", pod.Name)
+			failed = true
+			continue
+		}
+
+		c := runtimeapi.NewClient(net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(runtimeAPI.Port))))
+		if err := syncRuntimeCertificates(c, desired, deadline); err != nil {
+			logger.Error(err, "Unable to update certificates using the runtime API", "pod", pod.Name)
+			failed = true
+		}
+	}
+
+	return failed
+}
+
+// syncRuntimeCertificates pushes the certificates and crt-list entries to a pod. It stops at the first error which
+// is not reported by HAProxy, e.g. an unreachable pod, or when the deadline passed.
+func syncRuntimeCertificates(c *runtimeapi.Client, desired runtimeCertificates, deadline time.Time) error {
+	var errs error
+	push := func(name string, fn func() error) bool {
+		if time.Now().After(deadline) {
+			errs = multierr.Append(errs, fmt.Errorf("timeout before updating %s", name))
+			return false
+		}
+		err := fn()
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		var netErr net.Error
+		return !errors.As(err, &netErr)
+	}
+
+	for path, data := range desired.certificates {
+		if !push(path, func() error { return syncRuntimeCertificate(c, path, data) }) {
+			return errs
+		}
+	}
+	for path, entries := range desired.crtLists {
+		if !push(path, func() error { return syncRuntimeCrtList(c, path, entries) }) {
+			return errs
+		}
+	}
+
+	return errs
+}
+
+// syncRuntimeCertificate creates or replaces a certificate if its fingerprint differs from the loaded certificate.
+// Data without a certificate is ignored. Temporary certificates are only created, so they never replace a
+// certificate issued by ACME in the pod before it is written back into its Secret.
+func syncRuntimeCertificate(c *runtimeapi.Client, path, data string) error {
+	certificates, err := certs.Certificates([]byte(data))
+	if err != nil || len(certificates) == 0 {
+		return err
+	}
+	placeholder := slices.Contains(certificates[0].Subject.OrganizationalUnit, placeholderCertificateUnit)
+
+	// HAProxy reports the SHA1 fingerprint of certificates
+	// #nosec
+	fingerprint := fmt.Sprintf("%X", sha1.Sum(certificates[0].Raw))
+
+	loaded, found, err := c.SSLCertificateFingerprint(path)
+	if err != nil {
+		return err
+	}
+	if found && (placeholder || strings.EqualFold(loaded, fingerprint)) {
+		return nil
+	}
+
+	if !found {
+		if err := c.NewSSLCertificate(path); err != nil {
+			return err
+		}
+	}
+
+	return c.SetSSLCertificate(path, data)
+}
+
+// syncRuntimeCrtList adds missing entries, replaces changed entries and removes stale entries including their
+// certificates. Entries are compared by their certificate, options and SNI filters.
+func syncRuntimeCrtList(c *runtimeapi.Client, path string, entries []string) error {
+	loaded, err := c.SSLCrtListEntries(path)
+	if err != nil {
+		return err
+	}
+
+	current := map[string]string{}
+	for _, entry := range loaded {
+		current[strings.Fields(entry)[0]] = entry
+	}
+
+	var errs error
+	desired := map[string]bool{}
+	for _, entry := range entries {
+		certificate := strings.Fields(entry)[0]
+		desired[certificate] = true
+
+		existing, ok := current[certificate]
+		if ok && crtListEntryKey(existing) == crtListEntryKey(entry) {
+			continue
+		}
+		if ok {
+			if err := c.DeleteSSLCrtListEntry(path, certificate); err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+		}
+		errs = multierr.Append(errs, c.AddSSLCrtListEntry(path, entry))
+	}
+
+	for certificate := range current {
+		if desired[certificate] {
+			continue
+		}
+		if err := c.DeleteSSLCrtListEntry(path, certificate); err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		errs = multierr.Append(errs, c.DeleteSSLCertificate(certificate))
+	}
+
+	return errs
+}
+
+// crtListEntryKey returns the sorted fields of a crt-list entry, so entries are equal regardless of the order in
+// which HAProxy reports the options.
+func crtListEntryKey(entry string) string {
+	fields := strings.Fields(strings.NewReplacer("[", " ", "]", " ").Replace(entry))
+	slices.Sort(fields)
+	return strings.Join(fields, " ")
+}
@@ -59,7 +59,7 @@ func (r *Reconciler) reconcileService(ctx context.Context, instance *proxyv1alph
 					Name:       bind.Name,
 					Port:       bind.Port,
 					TargetPort: intstr.FromInt32(bind.Port),
-					Protocol:   corev1.ProtocolTCP,
+					Protocol:   bindProtocol(bind),
 				})
 			}
 		}
@@ -74,7 +74,7 @@ func (r *Reconciler) reconcileService(ctx context.Context, instance *proxyv1alph
 					Name:       bind.Name,
 					Port:       bind.Port,
 					TargetPort: intstr.FromInt32(bind.Port),
-					Protocol:   corev1.ProtocolTCP,
+					Protocol:   bindProtocol(bind),
 				})
 			}
 		}
@@ -167,12 +167,26 @@ func (r *Reconciler) reconcileServiceEndpoints(ctx context.Context, instance *pr
 	return nil
 }
 
+// bindProtocol returns the service port protocol of a bind, QUIC binds listen on UDP.
+func bindProtocol(bind configv1alpha1.Bind) corev1.Protocol {
+	if bind.QUIC {
+		return corev1.ProtocolUDP
+	}
+	return corev1.ProtocolTCP
+}
+
 func removeDuplicatesByPort(ports []corev1.ServicePort) []corev1.ServicePort {
-	seen := make(map[int32]bool)
+	type key struct {
+		port     int32
+		protocol corev1.Protocol
+	}
+
+	seen := make(map[key]bool)
 	var result []corev1.ServicePort
 	for _, item := range ports {
-		if !seen[item.Port] {
-			seen[item.Port] = true
+		k := key{port: item.Port, protocol: item.Protocol}
+		if !seen[k] {
+			seen[k] = true
 			result = append(result, item)
 		}
 	}
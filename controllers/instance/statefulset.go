@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -130,8 +131,8 @@ func (r *Reconciler) reconcileStatefulSet(ctx context.Context, instance *proxyv1
 								MountPath: filepath.Dir("/usr/local/etc/haproxy/"),
 							},
 						},
-						ReadinessProbe: instance.Spec.ReadinessProbe,
-						LivenessProbe:  instance.Spec.LivenessProbe,
+						ReadinessProbe: getReadinessProbe(instance),
+						LivenessProbe:  getLivenessProbe(instance),
 					},
 				},
 				Volumes: []corev1.Volume{
@@ -357,6 +358,64 @@ func getResources(instance *proxyv1alpha1.Instance) corev1.ResourceRequirements
 	return resources
 }
 
+// getReadinessProbe returns the readiness probe of the instance or, if the health frontend is enabled, a probe of
+// its monitor-uri, which also fails if a fail condition of the health frontend is true.
+func getReadinessProbe(instance *proxyv1alpha1.Instance) *corev1.Probe {
+	health := instance.Spec.Health
+	if instance.Spec.ReadinessProbe != nil || health == nil || !health.Enabled {
+		return instance.Spec.ReadinessProbe
+	}
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   health.MonitorURI(),
+				Port:   intstr.FromInt32(health.BindPort()),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		PeriodSeconds:    5,
+		TimeoutSeconds:   2,
+		SuccessThreshold: 1,
+		FailureThreshold: 2,
+	}
+}
+
+// getLivenessProbe returns the liveness probe of the instance or, if the health frontend is enabled, a probe of
+// the health port. It does not use the monitor-uri, so pods are not restarted if the backends are down.
+func getLivenessProbe(instance *proxyv1alpha1.Instance) *corev1.Probe {
+	health := instance.Spec.Health
+	if instance.Spec.LivenessProbe != nil || health == nil || !health.Enabled {
+		return instance.Spec.LivenessProbe
+	}
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromInt32(health.BindPort()),
+			},
+		},
+		InitialDelaySeconds: 10,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      2,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+}
+
+// validateHealthProbes rejects a health frontend bound to a loopback address if a default probe is used, as the
+// kubelet probes the address of the pod.
+func validateHealthProbes(instance *proxyv1alpha1.Instance) error {
+	health := instance.Spec.Health
+	if health == nil || !health.Enabled || health.Address == nil || (instance.Spec.ReadinessProbe != nil && instance.Spec.LivenessProbe != nil) {
+		return nil
+	}
+
+	if ip := net.ParseIP(*health.Address); *health.Address == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return fmt.Errorf("health address %s is not reachable by the default probes, readinessProbe and livenessProbe must be set", *health.Address)
+	}
+
+	return nil
+}
+
 func hasLocalLoggingTarget(instance *proxyv1alpha1.Instance) bool {
 	config := instance.Spec.Configuration.Global.Logging
 	return config != nil && config.Enabled && net.ParseIP(config.Address) == nil
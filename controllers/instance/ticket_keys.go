@@ -0,0 +1,212 @@
+package instance
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	"github.com/six-group/haproxy-operator/pkg/runtimeapi"
+	"github.com/six-group/haproxy-operator/pkg/utils"
+	"go.uber.org/multierr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// tlsTicketKeysRotatedAnnotation records the time of the last rotation of the TLS ticket keys.
+const tlsTicketKeysRotatedAnnotation = "proxy.haproxy.com/tls-ticket-keys-rotated"
+
+// tlsTicketKeysCount is the number of keys loaded by HAProxy. The penultimate key encrypts new tickets, all keys
+// decrypt them.
+const tlsTicketKeysCount = 3
+
+// tlsTicketKeySize is the size of a key for AES-256 encrypted tickets.
+const tlsTicketKeySize = 80
+
+// reconcileTLSTicketKeys creates the Secret holding the TLS ticket keys and rotates them. It returns the content of
+// the keys file and the time until the next rotation.
+func (r *Reconciler) reconcileTLSTicketKeys(ctx context.Context, instance *proxyv1alpha1.Instance) (string, time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	config := instance.Spec.Configuration.Global.TLSTicketKeys
+	if config == nil {
+		return "", 0, nil
+	}
+
+	file := filepath.Base(configv1alpha1.TLSTicketKeysFilePath)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GetTLSTicketKeysSecretName(instance),
+			Namespace: instance.Namespace,
+		},
+	}
+
+	var next time.Duration
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if err := controllerutil.SetOwnerReference(instance, secret, r.Scheme); err != nil {
+			return err
+		}
+
+		secret.Labels = utils.GetAppSelectorLabels(instance)
+
+		keys := strings.Fields(string(secret.Data[file]))
+		rotated, err := time.Parse(time.RFC3339, secret.Annotations[tlsTicketKeysRotatedAnnotation])
+		if err == nil && len(keys) == tlsTicketKeysCount && time.Since(rotated) < config.Interval() {
+			next = config.Interval() - time.Since(rotated)
+			return nil
+		}
+
+		// a new key replaces the oldest key, all keys are generated on creation
+		for range max(1, tlsTicketKeysCount-len(keys)) {
+			key, err := newTLSTicketKey()
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		keys = keys[len(keys)-tlsTicketKeysCount:]
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[tlsTicketKeysRotatedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		secret.Data = map[string][]byte{
+			file: []byte(strings.Join(keys, "\n") + "\n"),
+		}
+		next = config.Interval()
+
+		return nil
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	if result != controllerutil.OperationResultNone {
+		logger.Info(fmt.Sprintf("Object %s", result), "secret", secret.Name)
+	}
+
+	return string(secret.Data[file]), next, nil
+}
+
+// checkTLSTicketKeys verifies that binds only use TLS ticket keys if the instance generates them.
+func (r *Reconciler) checkTLSTicketKeys(ctx context.Context, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList) error {
+	if instance.Spec.Configuration.Global.TLSTicketKeys != nil {
+		return nil
+	}
+
+	for i := range listens.Items {
+		listen := listens.Items[i]
+		if err := checkBindsTLSTicketKeys(listen.Spec.Binds); err != nil {
+			listen.Status.Phase = configv1alpha1.StatusPhaseInternalError
+			listen.Status.Error = err.Error()
+			return multierr.Combine(err, r.Status().Update(ctx, &listen))
+		}
+	}
+
+	for i := range frontends.Items {
+		frontend := frontends.Items[i]
+		if err := checkBindsTLSTicketKeys(frontend.Spec.Binds); err != nil {
+			frontend.Status.Phase = configv1alpha1.StatusPhaseInternalError
+			frontend.Status.Error = err.Error()
+			return multierr.Combine(err, r.Status().Update(ctx, &frontend))
+		}
+	}
+
+	return nil
+}
+
+func checkBindsTLSTicketKeys(binds []configv1alpha1.Bind) error {
+	for _, bind := range binds {
+		if bind.SSL != nil && bind.SSL.TLSTicketKeys {
+			return fmt.Errorf("bind %s uses TLS ticket keys, but tlsTicketKeys is not configured in the instance", bind.Name)
+		}
+	}
+
+	return nil
+}
+
+// reconcileRuntimeTLSTicketKeys pushes the keys missing in the running pods using the Runtime API. Failures are
+// only logged, the pods load the keys with the next rollout.
+func (r *Reconciler) reconcileRuntimeTLSTicketKeys(ctx context.Context, instance *proxyv1alpha1.Instance, keys string, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList) {
+	logger := log.FromContext(ctx)
+
+	runtimeAPI := operatorRuntimeAPI(instance)
+	if runtimeAPI == nil || keys == "" || !usesTLSTicketKeys(listens, frontends) {
+		return
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(instance.Namespace), client.MatchingLabels(utils.GetAppSelectorLabels(instance))); err != nil {
+		logger.Error(err, "Unable to list pods")
+		return
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+			continue
+		}
+
+		c := runtimeapi.NewClient(net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(runtimeAPI.Port))))
+		if err := syncRuntimeTLSTicketKeys(c, strings.Fields(keys)); err != nil {
+			logger.Error(err, "Unable to update TLS ticket keys using the runtime API", "pod", pod.Name)
+		}
+	}
+}
+
+// syncRuntimeTLSTicketKeys adds the keys which are not loaded yet, from the oldest to the newest key.
+func syncRuntimeTLSTicketKeys(c *runtimeapi.Client, keys []string) error {
+	loaded, err := c.TLSTicketKeys(configv1alpha1.TLSTicketKeysFilePath)
+	if err != nil {
+		return err
+	}
+
+	// keys loaded by the pod are skipped, so only the keys generated after its last update are added
+	start := 0
+	for i, key := range keys {
+		if slices.Contains(loaded, key) {
+			start = i + 1
+		}
+	}
+
+	for _, key := range keys[start:] {
+		if err := c.SetTLSTicketKey(configv1alpha1.TLSTicketKeysFilePath, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func usesTLSTicketKeys(listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList) bool {
+	var binds []configv1alpha1.Bind
+	for _, listen := range listens.Items {
+		binds = append(binds, listen.Spec.Binds...)
+	}
+	for _, frontend := range frontends.Items {
+		binds = append(binds, frontend.Spec.Binds...)
+	}
+
+	return slices.ContainsFunc(binds, func(bind configv1alpha1.Bind) bool {
+		return bind.SSL != nil && bind.SSL.TLSTicketKeys
+	})
+}
+
+// newTLSTicketKey returns a random base64 encoded TLS ticket key.
+func newTLSTicketKey() (string, error) {
+	key := make([]byte, tlsTicketKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
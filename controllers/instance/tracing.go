@@ -0,0 +1,174 @@
+package instance
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	parser "github.com/haproxytech/client-native/v6/config-parser"
+	"github.com/haproxytech/client-native/v6/config-parser/common"
+	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
+	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// tracingFilterID is the id of the OpenTelemetry filter and the name of its section in the filter configuration.
+	tracingFilterID = "haproxy"
+	// tracingFilterConfigFilePath is the configuration of the OpenTelemetry filter with its scopes.
+	tracingFilterConfigFilePath = "/usr/local/etc/haproxy/otel.cfg"
+	// tracingSDKConfigFilePath is the configuration of the OpenTelemetry SDK with the exporter.
+	tracingSDKConfigFilePath = "/usr/local/etc/haproxy/otel.yml"
+)
+
+// tracingFilterConfigFormat creates one span per request, which is started when the backend receives the request
+// and finished with the response. The span context is injected into the request headers, so the servers continue
+// the trace.
+const tracingFilterConfigFormat = `[%s]
+    otel-instrumentation instrumentation
+        config %s
+        rate-limit %s
+        option dontlog-normal
+        scopes request
+        scopes response
+
+    otel-scope request
+        span "HAProxy request" root
+            attribute "http.request.method" method
+            attribute "url.path" path
+            attribute "server.address" req.hdr(host)
+            attribute "haproxy.frontend" fe_name
+            attribute "haproxy.backend" be_name
+            inject "otel_ctx" use-headers
+        event on-backend-http-request
+
+    otel-scope response
+        span "HAProxy request"
+            attribute "http.response.status_code" status
+            attribute "haproxy.server" srv_name
+        finish "HAProxy request"
+        event on-http-response
+`
+
+const tracingSDKConfigFormat = `exporters:
+  exporter_traces_otlp_http:
+    type: otlp_http
+    thread_name: "OTLP/HTTP traces"
+    endpoint: %s
+    content_type: protobuf
+processors:
+  processor_traces_batch:
+    type: batch
+    thread_name: "OTel traces batch"
+samplers:
+  sampler_traces:
+    type: always_on
+providers:
+  provider_traces:
+    resources:
+%s
+signals:
+  traces:
+    scope_name: "HAProxy"
+    exporters: exporter_traces_otlp_http
+    samplers: sampler_traces
+    processors: processor_traces_batch
+    providers: provider_traces
+`
+
+// generateTracingFiles returns the configuration files of the OpenTelemetry filter. The sampling is done by the
+// rate limit of the filter, so unsampled requests do not create spans.
+func generateTracingFiles(instance *proxyv1alpha1.Instance) map[string]string {
+	tracing := instance.Spec.Tracing
+	if tracing == nil {
+		return nil
+	}
+
+	rate := strconv.FormatFloat(float64(ptr.Deref(tracing.SamplingRate, 100)), 'f', 1, 64)
+
+	attributes := map[string]string{
+		"service.name":       tracing.ServiceName,
+		"k8s.namespace.name": instance.Namespace,
+	}
+	if attributes["service.name"] == "" {
+		attributes["service.name"] = "haproxy"
+	}
+	maps.Copy(attributes, tracing.ResourceAttributes)
+
+	var resources []string
+	for _, key := range slices.Sorted(maps.Keys(attributes)) {
+		resources = append(resources, fmt.Sprintf("      - %s: %s", strconv.Quote(key), strconv.Quote(attributes[key])))
+	}
+
+	return map[string]string{
+		tracingFilterConfigFilePath: fmt.Sprintf(tracingFilterConfigFormat, tracingFilterID, tracingSDKConfigFilePath, rate),
+		tracingSDKConfigFilePath:    fmt.Sprintf(tracingSDKConfigFormat, strconv.Quote(tracing.Endpoint), strings.Join(resources, "\n")),
+	}
+}
+
+// tracingFilter is the OpenTelemetry filter, which is not one of the filters known to the configuration parser.
+type tracingFilter struct{}
+
+func (tracingFilter) Parse(parts []string, comment string) error {
+	return fmt.Errorf("parsing of filter %s is not supported", strings.Join(parts, " "))
+}
+
+func (tracingFilter) Result() common.ReturnResultLine {
+	return common.ReturnResultLine{
+		Data: fmt.Sprintf("filter opentelemetry id %s config %s", tracingFilterID, tracingFilterConfigFilePath),
+	}
+}
+
+// addTracingFilters attaches the OpenTelemetry filter to the sections of the selected frontends, listens and
+// backends. Without a selector only the frontends and listens are traced. A listen is traced in its frontend
+// section, so its requests are traced once.
+func addTracingFilters(p parser.Parser, instance *proxyv1alpha1.Instance, listens *configv1alpha1.ListenList, frontends *configv1alpha1.FrontendList, backends *configv1alpha1.BackendList) error {
+	tracing := instance.Spec.Tracing
+	if tracing == nil {
+		return nil
+	}
+
+	selector := labels.Everything()
+	if tracing.LabelSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(tracing.LabelSelector); err != nil {
+			return err
+		}
+	}
+
+	var sections []string
+	for i := range listens.Items {
+		listen := &listens.Items[i]
+		if selector.Matches(labels.Set(listen.Labels)) {
+			sections = append(sections, listen.ToFrontend().Name)
+		}
+	}
+	for _, frontend := range frontends.Items {
+		if selector.Matches(labels.Set(frontend.Labels)) {
+			sections = append(sections, frontend.Name)
+		}
+	}
+	for _, name := range sections {
+		if err := p.Insert(parser.Frontends, name, "filter", tracingFilter{}); err != nil {
+			return err
+		}
+	}
+
+	if tracing.LabelSelector == nil {
+		return nil
+	}
+
+	for _, backend := range backends.Items {
+		if selector.Matches(labels.Set(backend.Labels)) {
+			if err := p.Insert(parser.Backends, backend.Name, "filter", tracingFilter{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
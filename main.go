@@ -119,6 +119,22 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Resolver")
 		os.Exit(1)
 	}
+	if err = (&config.Reconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Object: &configv1alpha1.Defaults{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Defaults")
+		os.Exit(1)
+	}
+	if err = (&config.Reconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Object: &configv1alpha1.CrtStore{},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CrtStore")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
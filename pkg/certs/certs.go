@@ -0,0 +1,241 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Certificates returns the certificates contained in PEM data in their order.
+func Certificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+// PrivateKey returns the first private key contained in PEM data, or nil if there is none.
+func PrivateKey(data []byte) (crypto.PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, nil
+		}
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			return x509.ParsePKCS8PrivateKey(block.Bytes)
+		default:
+			return nil, fmt.Errorf("unsupported private key type %s", block.Type)
+		}
+	}
+}
+
+// KeyMatches reports whether the private key belongs to the certificate.
+func KeyMatches(certificate *x509.Certificate, key crypto.PrivateKey) bool {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return false
+	}
+
+	public, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && public.Equal(certificate.PublicKey)
+}
+
+// Validate checks the certificates, private keys and CRLs contained in PEM data. A private key must belong to one of
+// the certificates, which is the leaf of the chain formed by the other certificates. If the certificates are not
+// ordered from the leaf to the root, the data is returned with the certificates reordered, followed by the private
+// key and the remaining blocks. Encrypted private keys and expired CA certificates in the chain are rejected. Data
+// without a private key, e.g. CA bundles and CRLs, is returned unchanged, as it may contain expired CA certificates
+// besides valid ones.
+func Validate(data []byte, now time.Time) ([]byte, error) {
+	var certificates []*x509.Certificate
+	var keys, others []*pem.Block
+
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		switch {
+		case block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED"):
+			return nil, fmt.Errorf("private key is encrypted")
+		case block.Type == "CERTIFICATE":
+			certificate, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("certificate %d: %w", len(certificates)+1, err)
+			}
+			certificates = append(certificates, certificate)
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			keys = append(keys, block)
+		case block.Type == "X509 CRL":
+			if _, err := x509.ParseRevocationList(block.Bytes); err != nil {
+				return nil, fmt.Errorf("crl: %w", err)
+			}
+			others = append(others, block)
+		default:
+			others = append(others, block)
+		}
+	}
+
+	if len(keys) == 0 {
+		return data, nil
+	}
+	if len(keys) > 1 {
+		return nil, fmt.Errorf("found %d private keys, expected one", len(keys))
+	}
+
+	key, err := PrivateKey(pem.EncodeToMemory(keys[0]))
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+
+	chain, err := chain(certificates, key)
+	if err != nil {
+		return nil, err
+	}
+	for _, certificate := range chain {
+		if certificate.IsCA && now.After(certificate.NotAfter) {
+			return nil, fmt.Errorf("CA certificate %q expired at %s", certificate.Subject, certificate.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+	if ordered(certificates, chain) {
+		return data, nil
+	}
+
+	var b []byte
+	for _, certificate := range chain {
+		b = append(b, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Raw})...)
+	}
+	b = append(b, pem.EncodeToMemory(keys[0])...)
+	for _, block := range others {
+		b = append(b, pem.EncodeToMemory(block)...)
+	}
+
+	return b, nil
+}
+
+// ValidateKey checks PEM data containing only the private key of a certificate, which is loaded from a separate
+// file. It must contain exactly one private key, which is not encrypted and belongs to one of the certificates.
+func ValidateKey(data, certificate []byte) error {
+	var keys []*pem.Block
+
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		switch {
+		case block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED"):
+			return fmt.Errorf("private key is encrypted")
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			keys = append(keys, block)
+		default:
+			return fmt.Errorf("unexpected %s in private key file", block.Type)
+		}
+	}
+
+	if len(keys) != 1 {
+		return fmt.Errorf("found %d private keys, expected one", len(keys))
+	}
+
+	key, err := PrivateKey(pem.EncodeToMemory(keys[0]))
+	if err != nil {
+		return fmt.Errorf("private key: %w", err)
+	}
+
+	certificates, err := Certificates(certificate)
+	if err != nil {
+		return err
+	}
+	if len(certificates) == 0 {
+		return fmt.Errorf("private key without certificate")
+	}
+	if !slices.ContainsFunc(certificates, func(certificate *x509.Certificate) bool {
+		return KeyMatches(certificate, key)
+	}) {
+		return fmt.Errorf("private key does not match certificate %q", certificates[0].Subject)
+	}
+
+	return nil
+}
+
+// chain returns the certificates ordered from the certificate of the private key to the root. Duplicate
+// certificates are removed.
+func chain(certificates []*x509.Certificate, key crypto.PrivateKey) ([]*x509.Certificate, error) {
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("private key without certificate")
+	}
+
+	leaf := slices.IndexFunc(certificates, func(certificate *x509.Certificate) bool {
+		return KeyMatches(certificate, key)
+	})
+	if leaf < 0 {
+		return nil, fmt.Errorf("private key does not match certificate %q", certificates[0].Subject)
+	}
+
+	chain := []*x509.Certificate{certificates[leaf]}
+	for {
+		current := chain[len(chain)-1]
+		next := slices.IndexFunc(certificates, func(certificate *x509.Certificate) bool {
+			return !contains(chain, certificate) && current.CheckSignatureFrom(certificate) == nil
+		})
+		if next < 0 {
+			break
+		}
+		chain = append(chain, certificates[next])
+	}
+
+	for _, certificate := range certificates {
+		if !contains(chain, certificate) {
+			return nil, fmt.Errorf("certificate %q is not part of the chain of %q", certificate.Subject, chain[0].Subject)
+		}
+	}
+
+	return chain, nil
+}
+
+// ordered reports whether the certificates are already in the order of the chain.
+func ordered(certificates, chain []*x509.Certificate) bool {
+	return slices.EqualFunc(certificates, chain, func(a, b *x509.Certificate) bool {
+		return a.Equal(b)
+	})
+}
+
+func contains(certificates []*x509.Certificate, certificate *x509.Certificate) bool {
+	return slices.ContainsFunc(certificates, certificate.Equal)
+}
@@ -0,0 +1,13 @@
+package certs_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCerts(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Certs Test Suite")
+}
@@ -0,0 +1,127 @@
+package certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/six-group/haproxy-operator/pkg/certs"
+)
+
+// certificate returns a PEM encoded certificate and private key signed by the parent, or self-signed if the parent
+// is nil.
+func certificate(name string, ca bool, notAfter time.Time, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (string, string, *x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-48 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  ca,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	Ω(err).ShouldNot(HaveOccurred())
+	parsed, err := x509.ParseCertificate(der)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})), parsed, key
+}
+
+var _ = Describe("Certs", Label("certs"), func() {
+	var (
+		now                        time.Time
+		rootPEM, intermediatePEM   string
+		leafPEM, leafKeyPEM        string
+		root, intermediate         *x509.Certificate
+		rootKey, intermediateKey   *ecdsa.PrivateKey
+		expiredPEM, expiredLeafPEM string
+		expiredLeafKeyPEM          string
+		expiredCA                  *x509.Certificate
+		expiredCAKey               *ecdsa.PrivateKey
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+
+		rootPEM, _, root, rootKey = certificate("Root CA", true, now.Add(time.Hour), nil, nil)
+		intermediatePEM, _, intermediate, intermediateKey = certificate("Intermediate CA", true, now.Add(time.Hour), root, rootKey)
+		leafPEM, leafKeyPEM, _, _ = certificate("example.com", false, now.Add(time.Hour), intermediate, intermediateKey)
+
+		expiredPEM, _, expiredCA, expiredCAKey = certificate("Expired CA", true, now.Add(-time.Hour), nil, nil)
+		expiredLeafPEM, expiredLeafKeyPEM, _, _ = certificate("expired.example.com", false, now.Add(time.Hour), expiredCA, expiredCAKey)
+	})
+
+	Context("Validate", func() {
+		It("should keep ordered chains", func() {
+			data := leafPEM + intermediatePEM + rootPEM + leafKeyPEM
+			Ω(certs.Validate([]byte(data), now)).Should(Equal([]byte(data)))
+		})
+
+		It("should reorder chains from the leaf to the root", func() {
+			data := rootPEM + leafKeyPEM + leafPEM + intermediatePEM
+			Ω(certs.Validate([]byte(data), now)).Should(Equal([]byte(leafPEM + intermediatePEM + rootPEM + leafKeyPEM)))
+		})
+
+		It("should reject encrypted private keys", func() {
+			encrypted := string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("key")}))
+			Ω(certs.Validate([]byte(leafPEM+encrypted), now)).Error().Should(MatchError("private key is encrypted"))
+
+			legacy := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Headers: map[string]string{"Proc-Type": "4,ENCRYPTED"}, Bytes: []byte("key")}))
+			Ω(certs.Validate([]byte(leafPEM+legacy), now)).Error().Should(MatchError("private key is encrypted"))
+		})
+
+		It("should reject private keys not matching a certificate", func() {
+			_, otherKeyPEM, _, _ := certificate("other.example.com", false, now.Add(time.Hour), nil, nil)
+			Ω(certs.Validate([]byte(leafPEM+otherKeyPEM), now)).Error().Should(MatchError(`private key does not match certificate "CN=example.com"`))
+		})
+
+		It("should reject certificates which are not part of the chain", func() {
+			Ω(certs.Validate([]byte(leafPEM+intermediatePEM+expiredPEM+leafKeyPEM), now)).Error().Should(MatchError(`certificate "CN=Expired CA" is not part of the chain of "CN=example.com"`))
+		})
+
+		It("should reject expired CA certificates of the chain", func() {
+			Ω(certs.Validate([]byte(expiredLeafPEM+expiredPEM+expiredLeafKeyPEM), now)).Error().Should(MatchError(HavePrefix(`CA certificate "CN=Expired CA" expired at`)))
+		})
+
+		It("should accept CA bundles with expired CA certificates", func() {
+			data := rootPEM + expiredPEM
+			Ω(certs.Validate([]byte(data), now)).Should(Equal([]byte(data)))
+		})
+
+		It("should return data without PEM blocks unchanged", func() {
+			Ω(certs.Validate([]byte("Certificate"), now)).Should(Equal([]byte("Certificate")))
+		})
+	})
+
+	Context("ValidateKey", func() {
+		It("should accept the key of the certificate", func() {
+			Ω(certs.ValidateKey([]byte(leafKeyPEM), []byte(leafPEM+intermediatePEM))).Should(Succeed())
+		})
+
+		It("should reject keys not matching the certificate", func() {
+			Ω(certs.ValidateKey([]byte(expiredLeafKeyPEM), []byte(leafPEM))).Should(MatchError(`private key does not match certificate "CN=example.com"`))
+		})
+
+		It("should reject certificates in the key file", func() {
+			Ω(certs.ValidateKey([]byte(leafKeyPEM+leafPEM), []byte(leafPEM))).Should(MatchError("unexpected CERTIFICATE in private key file"))
+		})
+	})
+})
@@ -0,0 +1,320 @@
+package runtimeapi
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 5 * time.Second
+
+var errorPrefixes = []string{"No such", "Require", "Unknown command", "Permission denied", "Can't", "Cannot", "Invalid", "Unable", "unable", "'"}
+
+// Client executes commands on the HAProxy Runtime API exposed on a TCP address.
+type Client struct {
+	Address string
+	Timeout time.Duration
+}
+
+func NewClient(address string) *Client {
+	return &Client{
+		Address: address,
+		Timeout: defaultTimeout,
+	}
+}
+
+// Execute sends a single command and returns the trimmed response. Responses reporting a failed command are
+// returned as error.
+func (c *Client) Execute(command string) (string, error) {
+	conn, err := net.DialTimeout("tcp", c.Address, c.Timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(c.Timeout)); err != nil {
+		return "", err
+	}
+
+	if _, err := conn.Write([]byte(command + "\n")); err != nil {
+		return "", err
+	}
+
+	data, err := io.ReadAll(conn)
+	if err != nil {
+		return "", err
+	}
+
+	response := strings.TrimSpace(string(data))
+	for _, prefix := range errorPrefixes {
+		if strings.HasPrefix(response, prefix) {
+			return response, fmt.Errorf("command '%s' failed: %s", command, response)
+		}
+	}
+
+	return response, nil
+}
+
+// checkConfigured is the flag of the srv_check_state column reporting a configured health check.
+const checkConfigured = 0x2
+
+// ServerState is the runtime state of a server as reported by 'show servers state' and 'show stat'.
+type ServerState struct {
+	Name    string
+	Address string
+	Port    string
+	// Weight is the configured weight, it is empty if it is not reported.
+	Weight string
+	SSL    bool
+	Check  bool
+	Backup bool
+}
+
+// ServersState returns the state of the servers of a backend keyed by server name.
+func (c *Client) ServersState(backend string) (map[string]ServerState, error) {
+	response, err := c.Execute("show servers state " + backend)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := map[string]ServerState{}
+	for _, values := range table(response, strings.Fields) {
+		check, _ := strconv.ParseInt(values["srv_check_state"], 10, 64)
+		servers[values["srv_name"]] = ServerState{
+			Name:    values["srv_name"],
+			Address: values["srv_addr"],
+			Port:    values["srv_port"],
+			Weight:  values["srv_uweight"],
+			SSL:     values["srv_use_ssl"] == "1",
+			Check:   check&checkConfigured != 0,
+		}
+	}
+
+	// the backup flag is only reported by the statistics
+	response, err = c.Execute(fmt.Sprintf("show stat %s 4 -1", backend))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, values := range table(response, func(line string) []string { return strings.Split(line, ",") }) {
+		if server, ok := servers[values["svname"]]; ok {
+			server.Backup = values["bck"] == "1"
+			servers[server.Name] = server
+		}
+	}
+
+	return servers, nil
+}
+
+// table returns the rows of a response with a header line starting with '#' as values keyed by column.
+func table(response string, split func(string) []string) []map[string]string {
+	var columns []string
+	var rows []map[string]string
+	for _, line := range strings.Split(response, "\n") {
+		if header, ok := strings.CutPrefix(line, "#"); ok {
+			columns = split(strings.TrimSpace(header))
+			continue
+		}
+
+		fields := split(strings.TrimSpace(line))
+		if len(columns) == 0 || len(fields) < len(columns) {
+			continue
+		}
+
+		values := map[string]string{}
+		for i, column := range columns {
+			values[column] = fields[i]
+		}
+		rows = append(rows, values)
+	}
+
+	return rows
+}
+
+// AddServer adds a dynamic server to a backend. The arguments are the address followed by the server keywords.
+func (c *Client) AddServer(backend, server, args string) error {
+	response, err := c.Execute(fmt.Sprintf("add server %s/%s %s", backend, server, args))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(response, "New server registered") {
+		return fmt.Errorf("unable to add server %s/%s: %s", backend, server, response)
+	}
+
+	return nil
+}
+
+// DeleteServer puts a server into maintenance and removes it from the backend. Removing fails while the server
+// still has connections, in which case it stays in maintenance.
+func (c *Client) DeleteServer(backend, server string) error {
+	if _, err := c.Execute(fmt.Sprintf("disable server %s/%s", backend, server)); err != nil {
+		return err
+	}
+
+	response, err := c.Execute(fmt.Sprintf("del server %s/%s", backend, server))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(response, "Server deleted") {
+		return fmt.Errorf("unable to delete server %s/%s: %s", backend, server, response)
+	}
+
+	return nil
+}
+
+// SetServerAddress updates the address and port of a server.
+func (c *Client) SetServerAddress(backend, server, address, port string) error {
+	_, err := c.Execute(fmt.Sprintf("set server %s/%s addr %s port %s", backend, server, address, port))
+	return err
+}
+
+// EnableServer enables a server and optionally its health checks.
+func (c *Client) EnableServer(backend, server string, health bool) error {
+	if _, err := c.Execute(fmt.Sprintf("enable server %s/%s", backend, server)); err != nil {
+		return err
+	}
+	if health {
+		if _, err := c.Execute(fmt.Sprintf("enable health %s/%s", backend, server)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RenewACMECertificate starts the ACME issuance of a certificate. The certificate is replaced in memory once it
+// is issued.
+func (c *Client) RenewACMECertificate(certificate string) error {
+	_, err := c.Execute("acme renew " + certificate)
+	return err
+}
+
+// DumpSSLCertificate returns the PEM encoded certificate chain and private key of a loaded certificate.
+func (c *Client) DumpSSLCertificate(certificate string) (string, error) {
+	return c.Execute("dump ssl cert " + certificate)
+}
+
+// SSLCertificateFingerprint returns the SHA1 fingerprint of a loaded certificate as reported by 'show ssl cert'. It
+// returns false if the certificate is not loaded.
+func (c *Client) SSLCertificateFingerprint(certificate string) (string, bool, error) {
+	response, err := c.Execute("show ssl cert " + certificate)
+	if err != nil {
+		if strings.Contains(response, "Not found") {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	for _, line := range strings.Split(response, "\n") {
+		if fingerprint, ok := strings.CutPrefix(line, "SHA1 FingerPrint:"); ok {
+			return strings.TrimSpace(fingerprint), true, nil
+		}
+	}
+
+	return "", true, nil
+}
+
+// NewSSLCertificate creates an empty certificate, which is filled using SetSSLCertificate.
+func (c *Client) NewSSLCertificate(certificate string) error {
+	_, err := c.Execute("new ssl cert " + certificate)
+	return err
+}
+
+// SetSSLCertificate replaces the certificate chain and private key of a certificate and commits the transaction.
+// Established connections keep using the previous certificate.
+func (c *Client) SetSSLCertificate(certificate, payload string) error {
+	if _, err := c.Execute(fmt.Sprintf("set ssl cert %s <<\n%s\n", certificate, strings.TrimSpace(payload))); err != nil {
+		return err
+	}
+
+	response, err := c.Execute("commit ssl cert " + certificate)
+	if err == nil && !strings.Contains(response, "Success!") {
+		err = fmt.Errorf("unable to commit certificate %s: %s", certificate, response)
+	}
+	if err != nil {
+		_, _ = c.Execute("abort ssl cert " + certificate)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSSLCertificate removes a certificate which is no longer used by any crt-list.
+func (c *Client) DeleteSSLCertificate(certificate string) error {
+	_, err := c.Execute("del ssl cert " + certificate)
+	return err
+}
+
+// SSLCrtListEntries returns the entries of a crt-list as reported by 'show ssl crt-list'.
+func (c *Client) SSLCrtListEntries(crtList string) ([]string, error) {
+	response, err := c.Execute("show ssl crt-list " + crtList)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []string
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+
+	return entries, nil
+}
+
+// AddSSLCrtListEntry adds an entry to a crt-list. The certificate of the entry must be loaded.
+func (c *Client) AddSSLCrtListEntry(crtList, entry string) error {
+	response, err := c.Execute(fmt.Sprintf("add ssl crt-list %s <<\n%s\n", crtList, entry))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(response, "Success!") {
+		return fmt.Errorf("unable to add %s to crt-list %s: %s", entry, crtList, response)
+	}
+
+	return nil
+}
+
+// DeleteSSLCrtListEntry removes the entries of a certificate from a crt-list.
+func (c *Client) DeleteSSLCrtListEntry(crtList, certificate string) error {
+	_, err := c.Execute(fmt.Sprintf("del ssl crt-list %s %s", crtList, certificate))
+	return err
+}
+
+// TLSTicketKeys returns the TLS ticket keys of a keys file as reported by 'show tls-keys', ordered from the oldest
+// to the newest key.
+func (c *Client) TLSTicketKeys(file string) ([]string, error) {
+	response, err := c.Execute("show tls-keys " + file)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, line := range strings.Split(response, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		keys = append(keys, fields[1])
+	}
+
+	return keys, nil
+}
+
+// SetTLSTicketKey adds a key to a keys file. It becomes the newest key and the oldest key is dropped.
+func (c *Client) SetTLSTicketKey(file, key string) error {
+	response, err := c.Execute(fmt.Sprintf("set ssl tls-key %s %s", file, key))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(response, "TLS ticket key updated") {
+		return fmt.Errorf("unable to update TLS ticket keys %s: %s", file, response)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package runtimeapi_test
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/six-group/haproxy-operator/pkg/runtimeapi"
+)
+
+// listen serves the Runtime API on a local port. The handler returns the response of a command, connections are
+// kept open without a response if it returns false.
+func listen(handler func(command string) (string, bool)) *runtimeapi.Client {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Ω(err).ShouldNot(HaveOccurred())
+	DeferCleanup(listener.Close)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			command, _ := bufio.NewReader(conn).ReadString('\n')
+			response, ok := handler(strings.TrimSpace(command))
+			if !ok {
+				DeferCleanup(conn.Close)
+				continue
+			}
+
+			_, _ = conn.Write([]byte(response))
+			_ = conn.Close()
+		}
+	}()
+
+	return runtimeapi.NewClient(listener.Addr().String())
+}
+
+var _ = Describe("Client", Label("runtimeapi"), func() {
+	Context("Execute", func() {
+		DescribeTable("should return the trimmed response",
+			func(response, expected string) {
+				c := listen(func(string) (string, bool) { return response, true })
+
+				result, err := c.Execute("show info")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(result).Should(Equal(expected))
+			},
+			Entry("with a single line", "Name: HAProxy\n", "Name: HAProxy"),
+			Entry("with multiple lines", "Name: HAProxy\nVersion: 3.2.0\n\n", "Name: HAProxy\nVersion: 3.2.0"),
+			Entry("without a response", "\n", ""),
+		)
+
+		DescribeTable("should return failed commands as error",
+			func(response string) {
+				c := listen(func(string) (string, bool) { return response + "\n", true })
+
+				result, err := c.Execute("del server web/web-1")
+				Ω(err).Should(MatchError("command 'del server web/web-1' failed: " + response))
+				Ω(result).Should(Equal(response))
+			},
+			Entry("No such", "No such server."),
+			Entry("Require", "Require 'backend/server'."),
+			Entry("Unknown command", "Unknown command: 'foo'"),
+			Entry("Permission denied", "Permission denied"),
+			Entry("Can't", "Can't find backend."),
+			Entry("Cannot", "Cannot delete server."),
+			Entry("Invalid", "Invalid server state."),
+			Entry("Unable", "Unable to allocate memory."),
+			Entry("unable", "unable to load certificate."),
+			Entry("quoted message", "'set server' only supports 'agent', 'health', 'state', 'weight', 'addr', 'fqdn' and 'check-port'."),
+		)
+
+		It("should fail if the response exceeds the timeout", func() {
+			c := listen(func(string) (string, bool) { return "", false })
+			c.Timeout = 100 * time.Millisecond
+
+			_, err := c.Execute("show info")
+			var netErr net.Error
+			Ω(errors.As(err, &netErr)).Should(BeTrue())
+			Ω(netErr.Timeout()).Should(BeTrue())
+		})
+
+		It("should fail if the address is not reachable", func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(listener.Close()).ShouldNot(HaveOccurred())
+
+			_, err = runtimeapi.NewClient(listener.Addr().String()).Execute("show info")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("ServersState", func() {
+		It("should return the state of the servers", func() {
+			c := listen(func(command string) (string, bool) {
+				switch command {
+				case "show servers state web":
+					return "1\n# be_id be_name srv_id srv_name srv_addr srv_op_state srv_admin_state srv_uweight srv_iweight srv_time_since_last_change srv_check_status srv_check_result srv_check_health srv_check_state srv_agent_state bk_f_forced_id srv_f_forced_id srv_fqdn srv_port srvrecord srv_use_ssl\n" +
+						"3 web 1 web-1 10.0.0.1 2 0 256 256 10 6 3 4 6 0 0 0 - 8080 - 1\n" +
+						"3 web 2 web-2 10.0.0.2 2 0 1 1 10 1 0 0 0 0 0 0 - 8080 - 0\n", true
+				case "show stat web 4 -1":
+					return "# pxname,svname,weight,act,bck,\nweb,web-1,256,1,0,\nweb,web-2,1,0,1,\n", true
+				}
+				return "Unknown command\n", true
+			})
+
+			servers, err := c.ServersState("web")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(servers).Should(Equal(map[string]runtimeapi.ServerState{
+				"web-1": {Name: "web-1", Address: "10.0.0.1", Port: "8080", Weight: "256", SSL: true, Check: true},
+				"web-2": {Name: "web-2", Address: "10.0.0.2", Port: "8080", Weight: "1", Backup: true},
+			}))
+		})
+	})
+
+	Context("AddServer", func() {
+		DescribeTable("should check the response",
+			func(response string, matcher OmegaMatcher) {
+				c := listen(func(string) (string, bool) { return response, true })
+				Ω(c.AddServer("web", "web-1", "10.0.0.1:8080 check")).Should(matcher)
+			},
+			Entry("registered", "New server registered.\n", Succeed()),
+			Entry("rejected", "Already exists a server with the same name in backend.\n", MatchError("unable to add server web/web-1: Already exists a server with the same name in backend.")),
+			Entry("failed", "No such backend.\n", MatchError("command 'add server web/web-1 10.0.0.1:8080 check' failed: No such backend.")),
+		)
+	})
+})
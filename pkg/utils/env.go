@@ -5,6 +5,10 @@ import "os"
 const (
 	HelperImageEnv  = "HELPER_IMAGE"
 	RsyslogImageEnv = "RSYSLOG_IMAGE"
+	// OperatorNameEnv is the value of the app label of the operator pods.
+	OperatorNameEnv = "OPERATOR_NAME"
+	// OperatorNamespaceEnv is the namespace of the operator pods.
+	OperatorNamespaceEnv = "OPERATOR_NAMESPACE"
 )
 
 func GetHelperImage() string {
@@ -14,3 +18,11 @@ func GetHelperImage() string {
 func GetRsyslogImage() string {
 	return os.Getenv(RsyslogImageEnv)
 }
+
+func GetOperatorName() string {
+	return os.Getenv(OperatorNameEnv)
+}
+
+func GetOperatorNamespace() string {
+	return os.Getenv(OperatorNamespaceEnv)
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 
 	configv1alpha1 "github.com/six-group/haproxy-operator/apis/config/v1alpha1"
 	proxyv1alpha1 "github.com/six-group/haproxy-operator/apis/proxy/v1alpha1"
@@ -22,3 +23,12 @@ func GetRouteName(frontend *configv1alpha1.Frontend, bind configv1alpha1.Bind) s
 
 	return fmt.Sprintf("%s-haproxy", frontend.Name)
 }
+
+func GetCertificateName(instance *proxyv1alpha1.Instance, certificate *configv1alpha1.SSLCertificate) string {
+	name := strings.TrimSuffix(strings.ToLower(certificate.Name), ".crt")
+	return fmt.Sprintf("%s-haproxy-%s", instance.Name, strings.NewReplacer("_", "-", ".", "-").Replace(name))
+}
+
+func GetTLSTicketKeysSecretName(instance *proxyv1alpha1.Instance) string {
+	return fmt.Sprintf("%s-haproxy-tls-ticket-keys", instance.Name)
+}